@@ -19,7 +19,7 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
             identifier_position: FilePosition {
                 path: file_path.to_string(),
                 position: Position {
-                    line: 57,
+                    line: 70,
                     character: 5,
                 },
             },
@@ -27,11 +27,35 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
                 path: file_path.to_string(),
                 range: api_types::Range {
                     start: Position {
-                        line: 57,
+                        line: 70,
                         character: 0,
                     },
                     end: Position {
-                        line: 122,
+                        line: 78,
+                        character: 1,
+                    },
+                },
+            },
+        },
+        Symbol {
+            name: "FindPathWithHeuristic".to_string(),
+            kind: "function".to_string(),
+            identifier_position: FilePosition {
+                path: file_path.to_string(),
+                position: Position {
+                    line: 84,
+                    character: 5,
+                },
+            },
+            file_range: FileRange {
+                path: file_path.to_string(),
+                range: api_types::Range {
+                    start: Position {
+                        line: 84,
+                        character: 0,
+                    },
+                    end: Position {
+                        line: 90,
                         character: 1,
                     },
                 },
@@ -43,7 +67,7 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
             identifier_position: FilePosition {
                 path: file_path.to_string(),
                 position: Position {
-                    line: 41,
+                    line: 57,
                     character: 5,
                 },
             },
@@ -51,11 +75,11 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
                 path: file_path.to_string(),
                 range: api_types::Range {
                     start: Position {
-                        line: 41,
+                        line: 57,
                         character: 0,
                     },
                     end: Position {
-                        line: 54,
+                        line: 59,
                         character: 1,
                     },
                 },
@@ -67,7 +91,7 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
             identifier_position: FilePosition {
                 path: file_path.to_string(),
                 position: Position {
-                    line: 17,
+                    line: 19,
                     character: 18,
                 },
             },
@@ -75,12 +99,12 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
                 path: file_path.to_string(),
                 range: api_types::Range {
                     start: Position {
-                        line: 17,
+                        line: 19,
                         character: 0,
                     },
                     end: Position {
-                        line: 17,
-                        character: 55,
+                        line: 19,
+                        character: 45,
                     },
                 },
             },
@@ -91,7 +115,7 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
             identifier_position: FilePosition {
                 path: file_path.to_string(),
                 position: Position {
-                    line: 18,
+                    line: 20,
                     character: 18,
                 },
             },
@@ -99,12 +123,12 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
                 path: file_path.to_string(),
                 range: api_types::Range {
                     start: Position {
-                        line: 18,
+                        line: 20,
                         character: 0,
                     },
                     end: Position {
-                        line: 18,
-                        character: 64,
+                        line: 33,
+                        character: 1,
                     },
                 },
             },
@@ -115,7 +139,7 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
             identifier_position: FilePosition {
                 path: file_path.to_string(),
                 position: Position {
-                    line: 30,
+                    line: 45,
                     character: 19,
                 },
             },
@@ -123,11 +147,11 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
                 path: file_path.to_string(),
                 range: api_types::Range {
                     start: Position {
-                        line: 30,
+                        line: 45,
                         character: 0,
                     },
                     end: Position {
-                        line: 38,
+                        line: 53,
                         character: 1,
                     },
                 },
@@ -139,7 +163,7 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
             identifier_position: FilePosition {
                 path: file_path.to_string(),
                 position: Position {
-                    line: 24,
+                    line: 39,
                     character: 19,
                 },
             },
@@ -147,11 +171,11 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
                 path: file_path.to_string(),
                 range: api_types::Range {
                     start: Position {
-                        line: 24,
+                        line: 39,
                         character: 0,
                     },
                     end: Position {
-                        line: 29,
+                        line: 44,
                         character: 1,
                     },
                 },
@@ -163,7 +187,7 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
             identifier_position: FilePosition {
                 path: file_path.to_string(),
                 position: Position {
-                    line: 19,
+                    line: 34,
                     character: 18,
                 },
             },
@@ -171,11 +195,11 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
                 path: file_path.to_string(),
                 range: api_types::Range {
                     start: Position {
-                        line: 19,
+                        line: 34,
                         character: 0,
                     },
                     end: Position {
-                        line: 23,
+                        line: 38,
                         character: 1,
                     },
                 },
@@ -187,7 +211,7 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
             identifier_position: FilePosition {
                 path: file_path.to_string(),
                 position: Position {
-                    line: 15,
+                    line: 17,
                     character: 5,
                 },
             },
@@ -195,23 +219,47 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
                 path: file_path.to_string(),
                 range: api_types::Range {
                     start: Position {
-                        line: 15,
+                        line: 17,
                         character: 0,
                     },
                     end: Position {
-                        line: 15,
+                        line: 17,
                         character: 27,
                     },
                 },
             },
         },
+        Symbol {
+            name: "search".to_string(),
+            kind: "method".to_string(),
+            identifier_position: FilePosition {
+                path: file_path.to_string(),
+                position: Position {
+                    line: 122,
+                    character: 19,
+                },
+            },
+            file_range: FileRange {
+                path: file_path.to_string(),
+                range: api_types::Range {
+                    start: Position {
+                        line: 122,
+                        character: 0,
+                    },
+                    end: Position {
+                        line: 232,
+                        character: 1,
+                    },
+                },
+            },
+        },
         Symbol {
             name: "searchNode".to_string(),
             kind: "type".to_string(),
             identifier_position: FilePosition {
                 path: file_path.to_string(),
                 position: Position {
-                    line: 7,
+                    line: 8,
                     character: 5,
                 },
             },
@@ -219,11 +267,59 @@ async fn test_file_symbols() -> Result<(), Box<dyn std::error::Error>> {
                 path: file_path.to_string(),
                 range: api_types::Range {
                     start: Position {
-                        line: 7,
+                        line: 8,
                         character: 0,
                     },
                     end: Position {
-                        line: 12,
+                        line: 14,
+                        character: 1,
+                    },
+                },
+            },
+        },
+        Symbol {
+            name: "searchOptions".to_string(),
+            kind: "type".to_string(),
+            identifier_position: FilePosition {
+                path: file_path.to_string(),
+                position: Position {
+                    line: 93,
+                    character: 5,
+                },
+            },
+            file_range: FileRange {
+                path: file_path.to_string(),
+                range: api_types::Range {
+                    start: Position {
+                        line: 93,
+                        character: 0,
+                    },
+                    end: Position {
+                        line: 115,
+                        character: 1,
+                    },
+                },
+            },
+        },
+        Symbol {
+            name: "zeroHeuristic".to_string(),
+            kind: "function".to_string(),
+            identifier_position: FilePosition {
+                path: file_path.to_string(),
+                position: Position {
+                    line: 62,
+                    character: 5,
+                },
+            },
+            file_range: FileRange {
+                path: file_path.to_string(),
+                range: api_types::Range {
+                    start: Position {
+                        line: 62,
+                        character: 0,
+                    },
+                    end: Position {
+                        line: 64,
                         character: 1,
                     },
                 },
@@ -249,7 +345,7 @@ async fn test_references() -> Result<(), Box<dyn std::error::Error>> {
         .find_references(
             "golang_astar/search.go",
             lsp_types::Position {
-                line: 58,
+                line: 143,
                 character: 5,
             },
         )
@@ -262,11 +358,11 @@ async fn test_references() -> Result<(), Box<dyn std::error::Error>> {
                 .unwrap(),
             range: Range {
                 start: lsp_types::Position {
-                    line: 58,
+                    line: 143,
                     character: 1,
                 },
                 end: lsp_types::Position {
-                    line: 58,
+                    line: 143,
                     character: 8,
                 },
             },
@@ -277,11 +373,11 @@ async fn test_references() -> Result<(), Box<dyn std::error::Error>> {
                 .unwrap(),
             range: Range {
                 start: lsp_types::Position {
-                    line: 59,
+                    line: 144,
                     character: 11,
                 },
                 end: lsp_types::Position {
-                    line: 59,
+                    line: 144,
                     character: 18,
                 },
             },
@@ -292,11 +388,11 @@ async fn test_references() -> Result<(), Box<dyn std::error::Error>> {
                 .unwrap(),
             range: Range {
                 start: lsp_types::Position {
-                    line: 68,
+                    line: 153,
                     character: 11,
                 },
                 end: lsp_types::Position {
-                    line: 68,
+                    line: 153,
                     character: 18,
                 },
             },
@@ -307,12 +403,12 @@ async fn test_references() -> Result<(), Box<dyn std::error::Error>> {
                 .unwrap(),
             range: Range {
                 start: lsp_types::Position {
-                    line: 72,
-                    character: 5,
+                    line: 154,
+                    character: 17,
                 },
                 end: lsp_types::Position {
-                    line: 72,
-                    character: 12,
+                    line: 154,
+                    character: 24,
                 },
             },
         },
@@ -322,11 +418,26 @@ async fn test_references() -> Result<(), Box<dyn std::error::Error>> {
                 .unwrap(),
             range: Range {
                 start: lsp_types::Position {
-                    line: 73,
+                    line: 161,
+                    character: 20,
+                },
+                end: lsp_types::Position {
+                    line: 161,
+                    character: 27,
+                },
+            },
+        },
+        Location {
+            uri: format!("file://{}/golang_astar/search.go", go_sample_path())
+                .parse()
+                .unwrap(),
+            range: Range {
+                start: lsp_types::Position {
+                    line: 168,
                     character: 22,
                 },
                 end: lsp_types::Position {
-                    line: 73,
+                    line: 168,
                     character: 29,
                 },
             },
@@ -337,12 +448,12 @@ async fn test_references() -> Result<(), Box<dyn std::error::Error>> {
                 .unwrap(),
             range: Range {
                 start: lsp_types::Position {
-                    line: 96,
-                    character: 25,
+                    line: 202,
+                    character: 14,
                 },
                 end: lsp_types::Position {
-                    line: 96,
-                    character: 32,
+                    line: 202,
+                    character: 21,
                 },
             },
         },
@@ -352,11 +463,11 @@ async fn test_references() -> Result<(), Box<dyn std::error::Error>> {
                 .unwrap(),
             range: Range {
                 start: lsp_types::Position {
-                    line: 111,
+                    line: 219,
                     character: 14,
                 },
                 end: lsp_types::Position {
-                    line: 111,
+                    line: 219,
                     character: 21,
                 },
             },
@@ -367,11 +478,26 @@ async fn test_references() -> Result<(), Box<dyn std::error::Error>> {
                 .unwrap(),
             range: Range {
                 start: lsp_types::Position {
-                    line: 116,
+                    line: 221,
+                    character: 20,
+                },
+                end: lsp_types::Position {
+                    line: 221,
+                    character: 27,
+                },
+            },
+        },
+        Location {
+            uri: format!("file://{}/golang_astar/search.go", go_sample_path())
+                .parse()
+                .unwrap(),
+            range: Range {
+                start: lsp_types::Position {
+                    line: 226,
                     character: 13,
                 },
                 end: lsp_types::Position {
-                    line: 116,
+                    line: 226,
                     character: 20,
                 },
             },
@@ -425,11 +551,11 @@ async fn test_definition() -> Result<(), Box<dyn std::error::Error>> {
             .unwrap(),
         range: Range {
             start: lsp_types::Position {
-                line: 57,
+                line: 70,
                 character: 5,
             },
             end: lsp_types::Position {
-                line: 57,
+                line: 70,
                 character: 13,
             },
         },
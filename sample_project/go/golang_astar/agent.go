@@ -0,0 +1,36 @@
+package golang_astar
+
+// FindPathForAgent finds the cheapest path between start and goal when
+// costFn, rather than the grid's terrain, prices every move, so each kind of
+// unit can bring its own movement rules, such as boats that only cross
+// water. costFn is asked about each move to an adjacent cell inside the
+// grid, honoring Connectivity and wrapping, and returns a negative cost or
+// MaxCost for moves the agent cannot make. Barriers, Impassable, Weights
+// and Portals are left to costFn. As nothing bounds the costs it returns,
+// the search runs without an estimate, like Dijkstra.
+func FindPathForAgent(grid *Grid, start, goal Node, costFn func(from, to Node) Cost) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+	dirs := directions8
+	if grid.Connectivity == 4 {
+		dirs = directions4
+	}
+
+	path, cost, _ := search(grid, start, goal, searchOptions{
+		successors: func(current *searchNode) []Arc {
+			arcs := make([]Arc, 0, len(dirs))
+			for _, d := range dirs {
+				next := grid.wrap(current.pos.Add(d.X, d.Y))
+				if !grid.IsValidPosition(next) || next == current.pos {
+					continue
+				}
+				if c := costFn(current.pos, next); c >= 0 && c != MaxCost {
+					arcs = append(arcs, Arc{next, c})
+				}
+			}
+			return arcs
+		},
+	})
+	return path, cost
+}
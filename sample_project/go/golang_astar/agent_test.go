@@ -0,0 +1,54 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathForAgent(t *testing.T) {
+	// a boat that only sails the water in the middle row
+	g := NewGrid(6, 3)
+	water := func(n Node) bool { return n.Y == 1 }
+	boat := func(from, to Node) Cost {
+		if !water(to) {
+			return -1
+		}
+		return 2
+	}
+
+	path, cost := FindPathForAgent(g, Node{0, 1}, Node{5, 1}, boat)
+	if cost != 10 {
+		t.Errorf("FindPathForAgent = %v, %d; want cost 10", path, cost)
+	}
+	for _, n := range path {
+		if !water(n) {
+			t.Errorf("path %v leaves the water at %v", path, n)
+		}
+	}
+	if path, _ := FindPathForAgent(g, Node{0, 1}, Node{5, 0}, boat); path != nil {
+		t.Errorf("boat reached land: %v", path)
+	}
+}
+
+func TestFindPathForAgentWraps(t *testing.T) {
+	g := NewGrid(10, 1)
+	g.WrapX = true
+	step := func(from, to Node) Cost { return 1 }
+
+	// the short way round crosses the seam between x=9 and x=0
+	if path, cost := FindPathForAgent(g, Node{1, 0}, Node{8, 0}, step); cost != 3 {
+		t.Errorf("FindPathForAgent = %v, %d; want cost 3 across the seam", path, cost)
+	}
+
+	// a one-cell-tall grid wrapping vertically only leads back to itself
+	g = NewGrid(3, 1)
+	g.WrapY = true
+	calls := 0
+	FindPathForAgent(g, Node{0, 0}, Node{2, 0}, func(from, to Node) Cost {
+		if from == to {
+			t.Errorf("costFn asked about a move from %v onto itself", from)
+		}
+		calls++
+		return 1
+	})
+	if calls == 0 {
+		t.Error("costFn never called")
+	}
+}
@@ -0,0 +1,35 @@
+package golang_astar
+
+// MaxAllPairsCells is the most open cells AllPairsDistances will handle.
+// The table grows with the square of the open cells, so at this size it
+// already holds some sixteen million entries.
+const MaxAllPairsCells = 4096
+
+// AllPairsDistances returns the cost of the shortest path between every
+// pair of open cells, found with one Dijkstra search from each, so a small
+// fixed map can answer distance queries with two map lookups. Cells that
+// cannot reach one another have no entry. Barriers count as open cells,
+// since they can be crossed; impassable cells are left out. It returns nil
+// when the grid has more than MaxAllPairsCells open cells.
+func (g *Grid) AllPairsDistances() map[Node]map[Node]Cost {
+	var cells []Node
+	for x := 0; x < g.Width; x++ {
+		for y := 0; y < g.Height; y++ {
+			if n := (Node{x, y}); !g.Impassable[n] {
+				cells = append(cells, n)
+			}
+		}
+	}
+	if len(cells) > MaxAllPairsCells {
+		return nil
+	}
+
+	// one Searcher keeps its buffers from each source to the next
+	var s Searcher
+	dist := make(map[Node]map[Node]Cost, len(cells))
+	for _, from := range cells {
+		s.search(g, from, from, searchOptions{isGoal: never})
+		dist[from] = s.settled()
+	}
+	return dist
+}
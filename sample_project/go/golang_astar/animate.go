@@ -0,0 +1,177 @@
+package golang_astar
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+)
+
+// AnimationOptions sets how AnimateSearchWithOptions draws a search. Zero
+// fields take the value from DefaultAnimationOptions.
+type AnimationOptions struct {
+	// CellSize is the width and height of a cell in pixels
+	CellSize int
+	// Delay is how long each frame shows, in hundredths of a second, and
+	// FinalDelay how long the last frame, with the path, shows
+	Delay      int
+	FinalDelay int
+	// StepsPerFrame is the number of expansions between frames, to keep
+	// animations of large searches short
+	StepsPerFrame int
+
+	Open, Barrier, Impassable color.Color
+	Expanded, Frontier, Path  color.Color
+	Start, Goal               color.Color
+}
+
+// DefaultAnimationOptions is the look AnimateSearch uses
+var DefaultAnimationOptions = AnimationOptions{
+	CellSize:      8,
+	Delay:         5,
+	FinalDelay:    200,
+	StepsPerFrame: 1,
+	Open:          color.RGBA{0xff, 0xff, 0xff, 0xff},
+	Barrier:       color.RGBA{0x55, 0x55, 0x55, 0xff},
+	Impassable:    color.RGBA{0x11, 0x11, 0x11, 0xff},
+	Expanded:      color.RGBA{0xa6, 0xc8, 0xf0, 0xff},
+	Frontier:      color.RGBA{0xff, 0xb0, 0x40, 0xff},
+	Path:          color.RGBA{0x1e, 0x6f, 0xd9, 0xff},
+	Start:         color.RGBA{0x2c, 0xa0, 0x2c, 0xff},
+	Goal:          color.RGBA{0xd6, 0x27, 0x28, 0xff},
+}
+
+// palette indexes of the cell states an animation frame shows
+const (
+	animOpen uint8 = iota
+	animBarrier
+	animImpassable
+	animExpanded
+	animFrontier
+	animPath
+	animStart
+	animGoal
+)
+
+// withDefaults fills the zero fields of o from DefaultAnimationOptions
+func (o AnimationOptions) withDefaults() AnimationOptions {
+	d := DefaultAnimationOptions
+	if o.CellSize <= 0 {
+		o.CellSize = d.CellSize
+	}
+	if o.Delay <= 0 {
+		o.Delay = d.Delay
+	}
+	if o.FinalDelay <= 0 {
+		o.FinalDelay = d.FinalDelay
+	}
+	if o.StepsPerFrame <= 0 {
+		o.StepsPerFrame = d.StepsPerFrame
+	}
+	for _, f := range []struct {
+		field *color.Color
+		def   color.Color
+	}{
+		{&o.Open, d.Open},
+		{&o.Barrier, d.Barrier},
+		{&o.Impassable, d.Impassable},
+		{&o.Expanded, d.Expanded},
+		{&o.Frontier, d.Frontier},
+		{&o.Path, d.Path},
+		{&o.Start, d.Start},
+		{&o.Goal, d.Goal},
+	} {
+		if *f.field == nil {
+			*f.field = f.def
+		}
+	}
+	return o
+}
+
+// AnimateSearch writes an animated GIF of the search FindPath runs from
+// start to goal, in DefaultAnimationOptions
+func AnimateSearch(grid *Grid, start, goal Node, w io.Writer) error {
+	return AnimateSearchWithOptions(grid, start, goal, w, DefaultAnimationOptions)
+}
+
+// AnimateSearchWithOptions writes an animated GIF of the search FindPath
+// runs from start to goal: each frame shows the cells expanded so far and
+// the open set around them, and a last frame adds the path, if there is
+// one, for showing how a heuristic steers the search
+func AnimateSearchWithOptions(grid *Grid, start, goal Node, w io.Writer, opts AnimationOptions) error {
+	if !grid.IsValidPosition(start) {
+		return ErrStartInvalid
+	}
+	if !grid.IsValidPosition(goal) {
+		return ErrGoalInvalid
+	}
+	o := opts.withDefaults()
+	palette := color.Palette{o.Open, o.Barrier, o.Impassable, o.Expanded, o.Frontier, o.Path, o.Start, o.Goal}
+
+	// base holds each cell's state before the frontier and path are drawn
+	// over it, indexed by y*Width+x
+	base := make([]uint8, grid.Width*grid.Height)
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			n := Node{x, y}
+			switch {
+			case grid.Impassable[n]:
+				base[y*grid.Width+x] = animImpassable
+			case grid.IsBarrier(n):
+				base[y*grid.Width+x] = animBarrier
+			}
+		}
+	}
+	// a NeighborFunc may lead the search off the grid, where there is
+	// nothing to draw
+	set := func(cells []uint8, n Node, state uint8) {
+		if grid.IsValidPosition(n) {
+			cells[n.Y*grid.Width+n.X] = state
+		}
+	}
+	cells := make([]uint8, len(base))
+	anim := &gif.GIF{}
+	addFrame := func(frontier, path []Node, delay int) {
+		copy(cells, base)
+		for _, n := range frontier {
+			set(cells, n, animFrontier)
+		}
+		for _, n := range path {
+			set(cells, n, animPath)
+		}
+		set(cells, start, animStart)
+		set(cells, goal, animGoal)
+		anim.Image = append(anim.Image, renderCells(cells, grid.Width, grid.Height, o.CellSize, palette))
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	var s Searcher
+	steps := 0
+	s.OnStep = func(frontier []Node) {
+		if steps%o.StepsPerFrame == 0 {
+			addFrame(frontier, nil, o.Delay)
+		}
+		steps++
+	}
+	path, _, _ := s.search(grid, start, goal, searchOptions{
+		h: defaultHeuristic(grid),
+		onExpand: func(n Node, _, _, _ Cost) {
+			set(base, n, animExpanded)
+		},
+	})
+	addFrame(s.frontier(), path, o.FinalDelay)
+	return gif.EncodeAll(w, anim)
+}
+
+// renderCells draws each cell of a width by height grid as a square of
+// size pixels in the palette color its state indexes
+func renderCells(cells []uint8, width, height, size int, palette color.Palette) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, width*size, height*size), palette)
+	for y := 0; y < height*size; y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+width*size]
+		for x := range row {
+			row[x] = cells[(y/size)*width+x/size]
+		}
+	}
+	return img
+}
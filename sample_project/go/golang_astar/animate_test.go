@@ -0,0 +1,58 @@
+package golang_astar
+
+import (
+	"bytes"
+	"image/gif"
+	"testing"
+)
+
+func TestAnimateSearch(t *testing.T) {
+	g := NewRandomGrid(6, 4, 0.2, 86)
+	start, goal := Node{0, 0}, Node{5, 3}
+
+	// count the expansions the same search makes
+	steps := 0
+	s := Searcher{OnStep: func([]Node) { steps++ }}
+	s.FindPath(g, start, goal)
+
+	var buf bytes.Buffer
+	opts := AnimationOptions{CellSize: 3, StepsPerFrame: 2}
+	if err := AnimateSearchWithOptions(g, start, goal, &buf, opts); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a frame every two steps, and the last one with the path
+	if want := (steps+1)/2 + 1; len(anim.Image) != want {
+		t.Errorf("got %d frames, want %d for %d steps", len(anim.Image), want, steps)
+	}
+	if anim.Config.Width != 18 || anim.Config.Height != 12 {
+		t.Errorf("GIF is %dx%d, want 18x12", anim.Config.Width, anim.Config.Height)
+	}
+	for i, img := range anim.Image {
+		if b := img.Bounds(); b.Dx() != 18 || b.Dy() != 12 {
+			t.Errorf("frame %d is %v, want 18x12", i, b)
+		}
+	}
+}
+
+func TestAnimateSearchOffGridNeighbors(t *testing.T) {
+	g := NewGrid(4, 4)
+	// a teleporter off the edge of the map and back
+	g.NeighborFunc = func(n Node) []Arc {
+		arcs := g.GetNeighbors(n)
+		if n == (Node{0, 0}) {
+			arcs = append(arcs, Arc{Node{-1, 9}, 1})
+		}
+		return arcs
+	}
+	var buf bytes.Buffer
+	if err := AnimateSearch(g, Node{0, 0}, Node{3, 3}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gif.DecodeAll(&buf); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,205 @@
+package golang_astar
+
+import "container/heap"
+
+// araEntry is a node waiting in an AnytimeAStar open set
+type araEntry struct {
+	pos   Node
+	key   float64 // g + epsilon*h
+	index int
+}
+
+// araQueue orders entries by key; it implements heap.Interface
+type araQueue []*araEntry
+
+func (q araQueue) Len() int { return len(q) }
+func (q araQueue) Less(i, j int) bool {
+	if q[i].key != q[j].key {
+		return q[i].key < q[j].key
+	}
+	return q[i].pos.less(q[j].pos)
+}
+func (q araQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *araQueue) Push(x interface{}) {
+	e := x.(*araEntry)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+func (q *araQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return e
+}
+
+// AnytimeAStar is an anytime planner in the style of ARA*. The first call
+// to Improve quickly finds a path with a heavily inflated heuristic, and
+// each later call lowers the inflation and repairs that path, reusing the
+// costs already found instead of searching from scratch, until the path is
+// the shortest. Create one with NewAnytimeAStar.
+type AnytimeAStar struct {
+	grid        *Grid
+	start, goal Node
+	epsilon     float64
+	step        float64
+	h           func(a, b Node) Cost
+
+	g       map[Node]Cost
+	parent  map[Node]Node
+	open    araQueue
+	queued  map[Node]*araEntry
+	closed  map[Node]bool
+	incons  map[Node]bool // improved after being closed this round
+	started bool
+	optimal bool
+}
+
+// NewAnytimeAStar prepares an anytime search from start to goal that first
+// inflates the heuristic by epsilon and lowers it by step on each later
+// call to Improve, down to 1. A step of zero or less goes straight to 1
+// after the first path.
+func NewAnytimeAStar(grid *Grid, start, goal Node, epsilon, step float64) *AnytimeAStar {
+	a := &AnytimeAStar{
+		grid:    grid,
+		start:   start,
+		goal:    goal,
+		epsilon: max(epsilon, 1),
+		step:    step,
+		h:       defaultHeuristic(grid),
+		g:       map[Node]Cost{start: 0},
+		parent:  make(map[Node]Node),
+		queued:  make(map[Node]*araEntry),
+		closed:  make(map[Node]bool),
+		incons:  make(map[Node]bool),
+	}
+	a.push(start)
+	return a
+}
+
+// gOf returns the best known cost of reaching n
+func (a *AnytimeAStar) gOf(n Node) Cost {
+	if g, ok := a.g[n]; ok {
+		return g
+	}
+	return MaxCost
+}
+
+// key returns n's priority for the current epsilon
+func (a *AnytimeAStar) key(n Node) float64 {
+	return float64(a.gOf(n)) + a.epsilon*float64(a.h(n, a.goal))
+}
+
+// push adds n to the open set or updates its key
+func (a *AnytimeAStar) push(n Node) {
+	if e, ok := a.queued[n]; ok {
+		e.key = a.key(n)
+		heap.Fix(&a.open, e.index)
+		return
+	}
+	e := &araEntry{pos: n, key: a.key(n)}
+	heap.Push(&a.open, e)
+	a.queued[n] = e
+}
+
+// improvePath expands nodes until none in the open set could lead to a
+// path cheaper than the current one under this round's epsilon
+func (a *AnytimeAStar) improvePath() {
+	for a.open.Len() > 0 && float64(a.gOf(a.goal)) > a.open[0].key {
+		e := heap.Pop(&a.open).(*araEntry)
+		delete(a.queued, e.pos)
+		a.closed[e.pos] = true
+
+		g := a.gOf(e.pos)
+		for _, arc := range a.grid.Neighbors(e.pos) {
+			next := addCost(g, arc.Cost)
+			if next >= a.gOf(arc.To) {
+				continue
+			}
+			a.g[arc.To] = next
+			a.parent[arc.To] = e.pos
+			if a.closed[arc.To] {
+				a.incons[arc.To] = true
+			} else {
+				a.push(arc.To)
+			}
+		}
+	}
+}
+
+// bound returns how many times the cost of the shortest path a path of the
+// given cost may cost at most
+func (a *AnytimeAStar) bound(cost Cost) float64 {
+	lowest := MaxCost
+	for _, e := range a.open {
+		lowest = min(lowest, addCost(a.gOf(e.pos), a.h(e.pos, a.goal)))
+	}
+	for n := range a.incons {
+		lowest = min(lowest, addCost(a.gOf(n), a.h(n, a.goal)))
+	}
+	if lowest == MaxCost || lowest == 0 {
+		return 1
+	}
+	return max(1, min(a.epsilon, float64(cost)/float64(lowest)))
+}
+
+// Improve returns a path from start to goal, its cost and a bound on how
+// many times the cost of the shortest path it may cost. Each call after the
+// first lowers epsilon and improves on the last path; once the bound
+// reaches 1 the path is the shortest and further calls return it again. A
+// nil path means the goal cannot be reached.
+func (a *AnytimeAStar) Improve() ([]Node, Cost, float64) {
+	if !a.grid.IsValidPosition(a.start) || !a.grid.IsValidPosition(a.goal) {
+		return nil, 0, 1
+	}
+	if a.started && !a.optimal {
+		if a.step > 0 {
+			a.epsilon = max(1, a.epsilon-a.step)
+		} else {
+			a.epsilon = 1
+		}
+		// Nodes improved after they were closed get another look, and every
+		// key changes with epsilon
+		for n := range a.incons {
+			a.push(n)
+		}
+		clear(a.incons)
+		for _, e := range a.open {
+			e.key = a.key(e.pos)
+		}
+		heap.Init(&a.open)
+		clear(a.closed)
+	}
+	if !a.optimal {
+		a.started = true
+		a.improvePath()
+	}
+
+	if _, ok := a.g[a.goal]; !ok {
+		return nil, 0, 1
+	}
+	// Costs found after the goal was reached may have shortened the parent
+	// chain below the goal's recorded cost, so the path is costed as walked
+	path := a.path()
+	cost, _ := a.grid.PathCost(path)
+	bound := a.bound(cost)
+	a.optimal = bound <= 1
+	return path, cost, bound
+}
+
+// path follows the parents back from the goal
+func (a *AnytimeAStar) path() []Node {
+	path := []Node{a.goal}
+	for n := a.goal; n != a.start; {
+		n = a.parent[n]
+		path = append(path, n)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
@@ -0,0 +1,57 @@
+package golang_astar
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAnytimeAStarImproves(t *testing.T) {
+	improved := 0
+	for seed := int64(0); seed < 20; seed++ {
+		// scattered rough ground, which the inflated heuristic walks into
+		r := rand.New(rand.NewSource(seed))
+		g := NewGrid(40, 40)
+		for i := 0; i < 600; i++ {
+			g.Weights[Node{r.Intn(40), r.Intn(40)}] = Cost(2 + r.Intn(4))
+		}
+		start, goal := Node{0, 0}, Node{39, 39}
+		_, optimal := FindPath(g, start, goal)
+
+		a := NewAnytimeAStar(g, start, goal, 3, 0.5)
+		lastCost, lastBound := MaxCost, 3.0
+		for round := 0; ; round++ {
+			if round == 10 {
+				t.Fatalf("seed %d: no optimal path after %d rounds", seed, round)
+			}
+			path, cost, bound := a.Improve()
+			if err := g.checkPath(path, start, goal, cost); err != nil {
+				t.Fatalf("seed %d round %d: path %v: %v", seed, round, path, err)
+			}
+			if cost > lastCost || bound > lastBound {
+				t.Fatalf("seed %d round %d: cost %d bound %.2f after cost %d bound %.2f",
+					seed, round, cost, bound, lastCost, lastBound)
+			}
+			if float64(cost) > bound*float64(optimal) {
+				t.Fatalf("seed %d round %d: cost %d is more than %.2f times the shortest %d",
+					seed, round, cost, bound, optimal)
+			}
+			if cost < lastCost && round > 0 {
+				improved++
+			}
+			lastCost, lastBound = cost, bound
+			if bound <= 1 {
+				break
+			}
+		}
+		if lastCost != optimal {
+			t.Errorf("seed %d: final cost %d, want the shortest %d", seed, lastCost, optimal)
+		}
+		// once optimal, further calls return the same path
+		if _, cost, bound := a.Improve(); cost != optimal || bound != 1 {
+			t.Errorf("seed %d: Improve after the optimum = %d, %.2f", seed, cost, bound)
+		}
+	}
+	if improved == 0 {
+		t.Error("no later round ever improved on the first path")
+	}
+}
@@ -0,0 +1,89 @@
+package golang_astar
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewGridFromStrings creates a grid from an ASCII map, one string per row
+// from y=0 down. Cells holding the wall rune become barriers; every row must
+// be the same length.
+func NewGridFromStrings(rows []string, wall rune) (*Grid, error) {
+	width := 0
+	if len(rows) > 0 {
+		width = len([]rune(rows[0]))
+	}
+
+	grid := NewGrid(width, len(rows))
+	for y, row := range rows {
+		cells := []rune(row)
+		if len(cells) != width {
+			return nil, fmt.Errorf("astar: row %d has %d cells, want %d", y, len(cells), width)
+		}
+		for x, c := range cells {
+			if c == wall {
+				grid.Barriers[Node{x, y}] = true
+			}
+		}
+	}
+	return grid, nil
+}
+
+// NewGridFromStringsWithMarkers creates a grid like NewGridFromStrings and
+// also returns the positions of the start and goal runes, each of which must
+// appear exactly once
+func NewGridFromStringsWithMarkers(rows []string, wall, start, goal rune) (*Grid, Node, Node, error) {
+	grid, err := NewGridFromStrings(rows, wall)
+	if err != nil {
+		return nil, Node{}, Node{}, err
+	}
+
+	var found [2][]Node
+	for y, row := range rows {
+		for x, c := range []rune(row) {
+			switch c {
+			case start:
+				found[0] = append(found[0], Node{x, y})
+			case goal:
+				found[1] = append(found[1], Node{x, y})
+			}
+		}
+	}
+	for i, marker := range []rune{start, goal} {
+		if len(found[i]) != 1 {
+			return nil, Node{}, Node{}, fmt.Errorf("astar: marker %q appears %d times, want 1", marker, len(found[i]))
+		}
+	}
+	return grid, found[0][0], found[1][0], nil
+}
+
+// RenderPath draws the grid as ASCII art, one line per row: '#' for
+// barriers and impassable cells, '.' for open cells and '*' along path, with
+// the first and last nodes of path marked 'S' and 'G'
+func (g *Grid) RenderPath(path []Node) string {
+	marks := make(map[Node]byte, len(path))
+	for _, n := range path {
+		marks[n] = '*'
+	}
+	if len(path) > 0 {
+		marks[path[0]] = 'S'
+		marks[path[len(path)-1]] = 'G'
+	}
+
+	var b strings.Builder
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			n := Node{x, y}
+			switch {
+			case marks[n] != 0:
+				b.WriteByte(marks[n])
+			case g.IsBarrier(n) || g.Impassable[n]:
+				b.WriteByte('#')
+			default:
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
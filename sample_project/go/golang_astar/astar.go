@@ -1,5 +1,11 @@
 // Package astar implements the A* search algorithm with minimal constraints
 // on the graph representation.
+//
+// Every search allocates its own state and only reads the Grid, so any
+// number of searches may run concurrently over one shared Grid, provided
+// nothing modifies the grid while they run and any NeighborFunc set on it
+// is itself safe for concurrent use. A Searcher, which reuses its state,
+// must only be used by one goroutine at a time.
 package golang_astar
 
 import "container/heap"
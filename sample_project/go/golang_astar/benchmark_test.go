@@ -0,0 +1,62 @@
+package golang_astar
+
+import "testing"
+
+// benchmarkMap is a named grid with a start and goal, for timing searches
+type benchmarkMap struct {
+	name        string
+	grid        *Grid
+	start, goal Node
+}
+
+// benchmarkMaps returns a fixed set of representative maps for tracking
+// search performance across versions: an empty grid, a dense random grid,
+// a maze and a large open grid with a far goal. The random and maze maps
+// come from the seeded generators, so every call builds the same grids.
+func benchmarkMaps() []benchmarkMap {
+	return []benchmarkMap{
+		{"empty", NewGrid(64, 64), Node{0, 0}, Node{63, 63}},
+		{"dense-random", NewRandomGrid(64, 64, 0.35, 1), Node{0, 0}, Node{63, 63}},
+		{"maze", NewMazeGrid(65, 65, 1), Node{0, 0}, Node{64, 64}},
+		{"large-open", NewGrid(512, 512), Node{0, 0}, Node{511, 480}},
+	}
+}
+
+// benchmarkSearch times find on each of maps
+func benchmarkSearch(b *testing.B, maps []benchmarkMap, find func(grid *Grid, start, goal Node) ([]Node, Cost)) {
+	for _, m := range maps {
+		b.Run(m.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				find(m.grid, m.start, m.goal)
+			}
+		})
+	}
+}
+
+func BenchmarkFindPath(b *testing.B) {
+	benchmarkSearch(b, benchmarkMaps(), func(grid *Grid, start, goal Node) ([]Node, Cost) {
+		return FindPath(grid, start, goal)
+	})
+}
+
+func BenchmarkFindPathPrecomputed(b *testing.B) {
+	maps := benchmarkMaps()
+	for _, m := range maps {
+		m.grid.Precompute()
+	}
+	benchmarkSearch(b, maps, func(grid *Grid, start, goal Node) ([]Node, Cost) {
+		return FindPath(grid, start, goal)
+	})
+}
+
+func BenchmarkFindPathSearcher(b *testing.B) {
+	var s Searcher
+	benchmarkSearch(b, benchmarkMaps(), func(grid *Grid, start, goal Node) ([]Node, Cost) {
+		return s.FindPath(grid, start, goal)
+	})
+}
+
+func BenchmarkFindPathBidirectional(b *testing.B) {
+	benchmarkSearch(b, benchmarkMaps(), FindPathBidirectional)
+}
@@ -0,0 +1,21 @@
+package golang_astar
+
+// FindPathBestEffort finds the shortest path between start and goal. When
+// the goal cannot be reached it instead returns the cheapest path to the
+// reachable cell nearest the goal by Heuristic, so a unit can walk as close
+// as it gets. The bool reports whether the goal itself was reached. If
+// start or goal lies outside the grid it returns nil.
+func FindPathBestEffort(grid *Grid, start, goal Node) ([]Node, Cost, bool) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0, false
+	}
+	var s Searcher
+	path, cost, _ := s.search(grid, start, goal, searchOptions{h: defaultHeuristic(grid)})
+	if path != nil {
+		return path, cost, true
+	}
+	if s.best == nil {
+		return nil, 0, false
+	}
+	return s.best.path(), s.best.g, false
+}
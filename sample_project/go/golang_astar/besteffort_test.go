@@ -0,0 +1,34 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathBestEffort(t *testing.T) {
+	g := NewGrid(10, 5)
+	g.Connectivity = 4
+	for y := 0; y < 5; y++ {
+		g.Impassable[Node{6, y}] = true
+	}
+
+	path, cost, reached := FindPathBestEffort(g, Node{0, 2}, Node{2, 2})
+	if !reached || cost != 2 {
+		t.Errorf("reachable goal: got %v, %d, %t; want cost 2", path, cost, reached)
+	}
+
+	// the wall cuts the goal off; the closest the search gets is beside it
+	path, cost, reached = FindPathBestEffort(g, Node{0, 2}, Node{9, 2})
+	if reached || len(path) == 0 || path[len(path)-1] != (Node{5, 2}) || cost != 5 {
+		t.Errorf("cut-off goal: got %v, %d, %t; want a path to (5,2) at cost 5", path, cost, reached)
+	}
+}
+
+func TestFindPathBestEffortOutOfBounds(t *testing.T) {
+	g := NewGrid(5, 5)
+	for _, n := range []Node{{-1, 0}, {0, -1}, {5, 0}, {0, 5}} {
+		if path, _, reached := FindPathBestEffort(g, n, Node{2, 2}); path != nil || reached {
+			t.Errorf("start %v: got %v, %t", n, path, reached)
+		}
+		if path, _, reached := FindPathBestEffort(g, Node{2, 2}, n); path != nil || reached {
+			t.Errorf("goal %v: got %v, %t", n, path, reached)
+		}
+	}
+}
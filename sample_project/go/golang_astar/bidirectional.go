@@ -0,0 +1,116 @@
+package golang_astar
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// frontier is one direction of a bidirectional search
+type frontier struct {
+	open    *nodeHeap
+	reached map[Node]*searchNode
+	target  Node
+	arcs    func(n Node) []Arc
+	h       func(a, b Node) Cost
+}
+
+func newFrontier(from, target Node, arcs func(n Node) []Arc, h func(a, b Node) Cost) *frontier {
+	root := &searchNode{pos: from, h: h(from, target)}
+	root.f = root.h
+	f := &frontier{
+		open:    &nodeHeap{},
+		reached: map[Node]*searchNode{from: root},
+		target:  target,
+		arcs:    arcs,
+		h:       h,
+	}
+	heap.Push(f.open, root)
+	return f
+}
+
+// minF is the lowest f still waiting in the open set
+func (f *frontier) minF() Cost {
+	return (*f.open)[0].f
+}
+
+// relax records the arc from current, returning the neighbor if its route
+// from the root improved
+func (f *frontier) relax(current *searchNode, arc Arc) *searchNode {
+	g := addCost(current.g, arc.Cost)
+	neighbor, ok := f.reached[arc.To]
+	if !ok {
+		neighbor = &searchNode{pos: arc.To, parent: current, g: g, h: f.h(arc.To, f.target)}
+		neighbor.f = addCost(g, neighbor.h)
+		f.reached[arc.To] = neighbor
+		heap.Push(f.open, neighbor)
+		return neighbor
+	}
+	if g >= neighbor.g {
+		return nil
+	}
+	neighbor.parent = current
+	neighbor.g = g
+	neighbor.f = addCost(g, neighbor.h)
+	if neighbor.index < 0 {
+		heap.Push(f.open, neighbor)
+	} else {
+		heap.Fix(f.open, neighbor.index)
+	}
+	return neighbor
+}
+
+// FindPathBidirectional finds the shortest path between start and goal by
+// searching forward from start and backward from goal at the same time,
+// stopping once no unexplored route can beat the best meeting point found.
+// If start or goal lies outside the grid it returns nil.
+func FindPathBidirectional(grid *Grid, start, goal Node) ([]Node, Cost) {
+	if outOfBounds(grid, start, goal) {
+		return nil, 0
+	}
+	h := defaultHeuristic(grid)
+	fwd := newFrontier(start, goal, grid.Neighbors, h)
+	bwd := newFrontier(goal, start, grid.predecessors, h)
+
+	found := start == goal
+	var best Cost
+	meet := start
+
+	for fwd.open.Len() > 0 && bwd.open.Len() > 0 {
+		bound := fwd.minF()
+		if f := bwd.minF(); f > bound {
+			bound = f
+		}
+		if found && best <= bound {
+			break
+		}
+
+		// grow whichever side has the smaller frontier
+		d, other := fwd, bwd
+		if bwd.open.Len() < fwd.open.Len() {
+			d, other = bwd, fwd
+		}
+
+		current := heap.Pop(d.open).(*searchNode)
+		for _, arc := range d.arcs(current.pos) {
+			neighbor := d.relax(current, arc)
+			if neighbor == nil {
+				continue
+			}
+			if opposite, ok := other.reached[arc.To]; ok {
+				if cost := addCost(neighbor.g, opposite.g); !found || cost < best {
+					found, best, meet = true, cost, arc.To
+				}
+			}
+		}
+	}
+
+	if !found {
+		return nil, 0 // No path found
+	}
+
+	// Stitch the forward half-path onto the reversed backward half, which
+	// runs from goal to meet
+	back := bwd.reached[meet].path()
+	slices.Reverse(back)
+	return append(fwd.reached[meet].path(), back[1:]...), best
+}
@@ -0,0 +1,49 @@
+package golang_astar
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFindPathBidirectionalMatchesFindPath(t *testing.T) {
+	r := rand.New(rand.NewSource(14))
+	for i := 0; i < 200; i++ {
+		g := NewRandomGrid(2+r.Intn(20), 2+r.Intn(20), 0.3, r.Int63())
+		if r.Intn(2) == 0 {
+			g.Connectivity = 4
+		}
+		if r.Intn(2) == 0 {
+			g.Impassable, g.Barriers = g.Barriers, make(map[Node]bool)
+		}
+		start := Node{r.Intn(g.Width), r.Intn(g.Height)}
+		goal := Node{r.Intn(g.Width), r.Intn(g.Height)}
+
+		want, wantCost := FindPath(g, start, goal)
+		path, cost := FindPathBidirectional(g, start, goal)
+		if (path == nil) != (want == nil) || cost != wantCost {
+			t.Fatalf("grid %d, %v -> %v: got %v at %d, want %v at %d",
+				i, start, goal, path, cost, want, wantCost)
+		}
+		if path == nil {
+			continue
+		}
+		if path[0] != start || path[len(path)-1] != goal {
+			t.Fatalf("grid %d: path %v does not run from %v to %v", i, path, start, goal)
+		}
+		if walked, err := g.PathCost(path); err != nil || walked != cost {
+			t.Fatalf("grid %d: path %v walks for %d (%v), reported %d", i, path, walked, err, cost)
+		}
+	}
+}
+
+func TestFindPathBidirectionalOutOfBounds(t *testing.T) {
+	g := NewGrid(5, 5)
+	for _, tt := range []struct{ start, goal Node }{
+		{Node{-1, 0}, Node{4, 4}},
+		{Node{0, 0}, Node{4, 5}},
+	} {
+		if path, cost := FindPathBidirectional(g, tt.start, tt.goal); path != nil {
+			t.Errorf("FindPathBidirectional(%v, %v) = %v, %d; want nil", tt.start, tt.goal, path, cost)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package golang_astar
+
+// NewDenseGrid creates a grid like NewGrid whose barriers are kept in a
+// bitset of one bit per cell instead of the Barriers map, which is much
+// smaller and faster for large grids with many barriers. Its Barriers map
+// is nil and never consulted: change barriers with SetBarrier and
+// ClearBarrier and read them with IsBarrier.
+func NewDenseGrid(width, height int) *Grid {
+	grid := NewGrid(width, height)
+	grid.Barriers = nil
+	grid.barrierBits = make([]uint64, (max(width*height, 0)+63)/64)
+	return grid
+}
+
+// IsBarrier reports whether n is a barrier
+func (g *Grid) IsBarrier(n Node) bool {
+	if g.barrierBits == nil {
+		return g.Barriers[n]
+	}
+	if !g.IsValidPosition(n) {
+		return false
+	}
+	i := g.bitIndex(n)
+	return g.barrierBits[i/64]&(1<<(i%64)) != 0
+}
+
+// bitIndex returns the position of n's bit in barrierBits
+func (g *Grid) bitIndex(n Node) int {
+	return n.Y*g.Width + n.X
+}
+
+// barrierList returns the barriers in a stable order
+func (g *Grid) barrierList() []Node {
+	if g.barrierBits == nil {
+		var nodes []Node
+		for _, n := range sortedKeys(g.Barriers) {
+			if g.Barriers[n] {
+				nodes = append(nodes, n)
+			}
+		}
+		return nodes
+	}
+	var nodes []Node
+	for x := 0; x < g.Width; x++ {
+		for y := 0; y < g.Height; y++ {
+			if n := (Node{x, y}); g.IsBarrier(n) {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,92 @@
+package golang_astar
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDenseGridMatchesMapGrid(t *testing.T) {
+	wall := []Node{{3, 0}, {3, 1}, {3, 2}, {3, 3}, {3, 4}, {3, 5}}
+	tests := []struct {
+		name   string
+		change func(g *Grid)
+	}{
+		{"empty", func(g *Grid) {}},
+		{"set", func(g *Grid) { g.SetBarriers(wall) }},
+		{"set off grid", func(g *Grid) { g.SetBarrier(Node{-1, 3}) }},
+		{"clear", func(g *Grid) {
+			g.SetBarriers(wall)
+			g.ClearBarrier(Node{3, 5})
+		}},
+		{"toggle", func(g *Grid) {
+			g.SetBarriers(wall)
+			g.ToggleBarrier(Node{3, 2})
+			g.ToggleBarrier(Node{4, 4})
+		}},
+		{"toggle twice", func(g *Grid) {
+			g.ToggleBarrier(Node{2, 2})
+			g.ToggleBarrier(Node{2, 2})
+		}},
+		{"clear all", func(g *Grid) {
+			g.SetBarriers(wall)
+			g.ClearBarriers()
+		}},
+	}
+	for _, tt := range tests {
+		sparse, dense := NewGrid(7, 7), NewDenseGrid(7, 7)
+		tt.change(sparse)
+		tt.change(dense)
+
+		if got, want := dense.barrierList(), sparse.barrierList(); !slices.Equal(got, want) {
+			t.Errorf("%s: dense barriers %v, map barriers %v", tt.name, got, want)
+		}
+		for _, goal := range []Node{{6, 0}, {6, 6}, {4, 4}} {
+			want, wantCost := FindPath(sparse, Node{0, 0}, goal)
+			if path, cost := FindPath(dense, Node{0, 0}, goal); cost != wantCost || !slices.Equal(path, want) {
+				t.Errorf("%s: dense FindPath to %v = %v, %d; map %v, %d",
+					tt.name, goal, path, cost, want, wantCost)
+			}
+		}
+	}
+}
+
+func BenchmarkIsBarrier(b *testing.B) {
+	grids := []struct {
+		name string
+		grid *Grid
+	}{
+		{"map", NewGrid(256, 256)},
+		{"dense", NewDenseGrid(256, 256)},
+	}
+	barriers := NewRandomGrid(256, 256, 0.3, 64).barrierList()
+	for _, bg := range grids {
+		bg.grid.SetBarriers(barriers)
+		b.Run(bg.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bg.grid.IsBarrier(Node{i % 256, i / 256 % 256})
+			}
+		})
+	}
+}
+
+// BenchmarkBarrierMemory builds a 256x256 grid with 30% barriers each
+// iteration, so B/op is the memory one grid takes; the rest of the grid is
+// the same either way, so the difference is the barrier storage
+func BenchmarkBarrierMemory(b *testing.B) {
+	barriers := NewRandomGrid(256, 256, 0.3, 64).barrierList()
+	for _, bg := range []struct {
+		name    string
+		newGrid func(width, height int) *Grid
+	}{
+		{"map", NewGrid},
+		{"dense", NewDenseGrid},
+	} {
+		b.Run(bg.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bg.newGrid(256, 256).SetBarriers(barriers)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package golang_astar
+
+import "errors"
+
+// errBudgetExhausted stops a search that ran out of expansions
+var errBudgetExhausted = errors.New("astar: expansion budget exhausted")
+
+// FindPathBounded finds the shortest path between start and goal, giving up
+// after maxExpansions nodes have been expanded so the cost of a call has a
+// fixed ceiling. The bool reports whether the budget ran out; the path
+// returned then leads to the expanded node nearest the goal, which an
+// agent can follow while the search is retried later. A non-positive
+// budget gives up at once. If start or goal lies outside the grid it
+// returns nil without spending the budget.
+func FindPathBounded(grid *Grid, start, goal Node, maxExpansions int) ([]Node, Cost, bool) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0, false
+	}
+	if maxExpansions <= 0 {
+		return nil, 0, true
+	}
+	var s Searcher
+	path, cost, err := s.search(grid, start, goal, searchOptions{
+		h:             defaultHeuristic(grid),
+		maxExpansions: maxExpansions,
+	})
+	if errors.Is(err, errBudgetExhausted) {
+		return s.best.path(), s.best.g, true
+	}
+	return path, cost, false
+}
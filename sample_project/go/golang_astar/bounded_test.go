@@ -0,0 +1,33 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathBounded(t *testing.T) {
+	g := NewGrid(30, 30)
+	start, goal := Node{0, 0}, Node{29, 29}
+
+	path, cost, exhausted := FindPathBounded(g, start, goal, 10000)
+	if _, want := FindPath(g, start, goal); exhausted || path == nil || cost != want {
+		t.Errorf("ample budget: got %v, %d, %t; want cost %d", path, cost, exhausted, want)
+	}
+
+	path, cost, exhausted = FindPathBounded(g, start, goal, 5)
+	if !exhausted || len(path) == 0 || path[0] != start {
+		t.Fatalf("small budget: got %v, %d, %t; want a partial path from %v", path, cost, exhausted, start)
+	}
+	if walked, err := g.PathCost(path); err != nil || walked != cost {
+		t.Errorf("partial path %v walks for %d (%v), reported %d", path, walked, err, cost)
+	}
+}
+
+func TestFindPathBoundedOutOfBounds(t *testing.T) {
+	g := NewGrid(5, 5)
+	for _, n := range []Node{{-1, 0}, {0, -1}, {5, 0}, {0, 5}} {
+		if path, _, exhausted := FindPathBounded(g, n, Node{2, 2}, 100); path != nil || exhausted {
+			t.Errorf("start %v: got %v, %t", n, path, exhausted)
+		}
+		if path, _, exhausted := FindPathBounded(g, Node{2, 2}, n, 100); path != nil || exhausted {
+			t.Errorf("goal %v: got %v, %t", n, path, exhausted)
+		}
+	}
+}
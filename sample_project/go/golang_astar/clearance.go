@@ -0,0 +1,134 @@
+package golang_astar
+
+import "math"
+
+// obstacleDistances runs a brushfire from every barrier and impassable
+// cell, returning each cell's Chebyshev distance to the nearest one,
+// indexed by y*Width+x. Cells are math.MaxInt away when there are no
+// obstacles at all.
+func (g *Grid) obstacleDistances() []int {
+	dist := make([]int, max(g.Width*g.Height, 0))
+	var queue []Node
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			n := Node{x, y}
+			if g.IsBarrier(n) || g.Impassable[n] {
+				queue = append(queue, n)
+			} else {
+				dist[y*g.Width+x] = math.MaxInt
+			}
+		}
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		d := dist[n.Y*g.Width+n.X] + 1
+		for _, step := range directions8 {
+			next := n.Add(step.X, step.Y)
+			if g.IsValidPosition(next) && dist[next.Y*g.Width+next.X] > d {
+				dist[next.Y*g.Width+next.X] = d
+				queue = append(queue, next)
+			}
+		}
+	}
+	return dist
+}
+
+// FindPathWithRadius finds the shortest path between start and goal for a
+// unit that covers every cell within radius steps of the cell it stands on,
+// a square 2*radius+1 cells across. Cells with a barrier or impassable cell
+// that close are off limits, as are barriers themselves, so the path keeps
+// clear of gaps too narrow for the unit. The grid edge does not count as an
+// obstacle. For units an even number of cells across, use FindPathWithSize.
+func FindPathWithRadius(grid *Grid, start, goal Node, radius int) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+	dist := grid.obstacleDistances()
+	return findPathFitting(grid, start, goal, func(n Node) bool {
+		return grid.IsValidPosition(n) && dist[n.Y*grid.Width+n.X] > radius
+	})
+}
+
+// FindPathWithSize finds the shortest path between start and goal for a
+// unit size cells across, such as a 2x2 vehicle. The unit's position is the
+// top-left cell of its footprint, which must lie on the grid clear of
+// barriers and impassable cells; with ClearanceMap, a cell is usable when
+// its clearance is at least size. The path lists the top-left cells. Sizes
+// below 1 are treated as 1, and the footprint never wraps across grid
+// edges.
+func FindPathWithSize(grid *Grid, start, goal Node, size int) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+	clearance := grid.clearances()
+	return findPathFitting(grid, start, goal, func(n Node) bool {
+		return grid.IsValidPosition(n) && clearance[n.Y*grid.Width+n.X] >= size
+	})
+}
+
+// findPathFitting searches over the cells where fits says a large unit can
+// stand. A diagonal move also sweeps the two positions beside it, so both
+// must fit too, which keeps the unit out of gaps narrower than itself.
+func findPathFitting(grid *Grid, start, goal Node, fits func(n Node) bool) ([]Node, Cost) {
+	if !fits(start) || !fits(goal) {
+		return nil, 0
+	}
+
+	path, cost, _ := search(grid, start, goal, searchOptions{
+		h: defaultHeuristic(grid),
+		successors: func(current *searchNode) []Arc {
+			n := current.pos
+			arcs := grid.Neighbors(n)
+			kept := make([]Arc, 0, len(arcs))
+			for _, arc := range arcs {
+				if !fits(arc.To) {
+					continue
+				}
+				d := grid.nearestCopy(n, arc.To).Sub(n)
+				if abs(d.X) == 1 && abs(d.Y) == 1 &&
+					(!fits(grid.wrap(n.Add(d.X, 0))) || !fits(grid.wrap(n.Add(0, d.Y)))) {
+					continue
+				}
+				kept = append(kept, arc)
+			}
+			return kept
+		},
+	})
+	return path, cost
+}
+
+// ClearanceMap returns, for each open cell, the size of the largest square
+// of open cells that has the cell as its top-left corner, so a unit n cells
+// across fits with its top-left corner on any cell of clearance n or more.
+// Barriers, impassable cells and the grid edge all bound the squares.
+func (g *Grid) ClearanceMap() map[Node]int {
+	clearance := make(map[Node]int)
+	for i, c := range g.clearances() {
+		if c > 0 {
+			clearance[Node{i % g.Width, i / g.Width}] = c
+		}
+	}
+	return clearance
+}
+
+// clearances computes ClearanceMap indexed by y*Width+x, with 0 for
+// blocked cells
+func (g *Grid) clearances() []int {
+	clearance := make([]int, max(g.Width*g.Height, 0))
+	at := func(x, y int) int {
+		if x >= g.Width || y >= g.Height {
+			return 0
+		}
+		return clearance[y*g.Width+x]
+	}
+	for y := g.Height - 1; y >= 0; y-- {
+		for x := g.Width - 1; x >= 0; x-- {
+			if n := (Node{x, y}); g.IsBarrier(n) || g.Impassable[n] {
+				continue
+			}
+			clearance[y*g.Width+x] = 1 + min(at(x+1, y), at(x, y+1), at(x+1, y+1))
+		}
+	}
+	return clearance
+}
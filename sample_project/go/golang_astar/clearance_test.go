@@ -0,0 +1,119 @@
+package golang_astar
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestFindPathWithSizeAvoidsNarrowGap(t *testing.T) {
+	// a wall down x=5 with a one-cell gap at y=1 and a two-cell gap at the
+	// bottom, in rows 5 and 6
+	g := NewGrid(10, 7)
+	for y := 0; y < 5; y++ {
+		if y != 1 {
+			g.Impassable[Node{5, y}] = true
+		}
+	}
+	start, goal := Node{0, 0}, Node{8, 0}
+
+	small, smallCost := FindPathWithSize(g, start, goal, 1)
+	if _, want := FindPath(g, start, goal); smallCost != want {
+		t.Errorf("size 1: FindPathWithSize = %v, %d; want cost %d", small, smallCost, want)
+	}
+
+	big, bigCost := FindPathWithSize(g, start, goal, 2)
+	if big == nil || bigCost <= smallCost {
+		t.Fatalf("size 2: FindPathWithSize = %v, %d; want a detour dearer than %d", big, bigCost, smallCost)
+	}
+	clearance := g.ClearanceMap()
+	for _, n := range big {
+		if clearance[n] < 2 {
+			t.Fatalf("size 2: path %v stops at %v, which has clearance %d", big, n, clearance[n])
+		}
+		if n.X == 5 && n.Y != 5 {
+			t.Fatalf("size 2: path %v crosses the wall at %v", big, n)
+		}
+	}
+	if walked, err := g.PathCost(big); err != nil || walked != bigCost {
+		t.Errorf("size 2: path %v walks for %d (%v), reported %d", big, walked, err, bigCost)
+	}
+
+	if path, _ := FindPathWithSize(g, start, Node{9, 0}, 2); path != nil {
+		t.Errorf("size 2: got path %v to a goal whose footprint hangs off the grid", path)
+	}
+}
+
+func TestFindPathWithSizeNoDiagonalSqueeze(t *testing.T) {
+	// a 2x2 unit moving diagonally from (0,0) to (1,1) would sweep over
+	// (2,0), though neither end of the move covers it
+	g := NewGrid(5, 5)
+	g.Impassable[Node{2, 0}] = true
+	want := []Node{{0, 0}, {0, 1}, {1, 1}}
+	if path, cost := FindPathWithSize(g, Node{0, 0}, Node{1, 1}, 2); cost != 2 || !slices.Equal(path, want) {
+		t.Errorf("FindPathWithSize = %v, %d; want %v at cost 2", path, cost, want)
+	}
+}
+
+func TestClearanceMapBottleneck(t *testing.T) {
+	// two open rooms joined by a one-cell doorway at (2,1)
+	g, err := NewGridFromStrings([]string{
+		"..#...",
+		"......",
+		"..#...",
+	}, '#')
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[Node]int{
+		{0, 0}: 2, {1, 0}: 1, {3, 0}: 3, {4, 0}: 2, {5, 0}: 1,
+		{0, 1}: 2, {1, 1}: 1, {2, 1}: 1, {3, 1}: 2, {4, 1}: 2, {5, 1}: 1,
+		{0, 2}: 1, {1, 2}: 1, {3, 2}: 1, {4, 2}: 1, {5, 2}: 1,
+	}
+	if got := g.ClearanceMap(); !maps.Equal(got, want) {
+		t.Errorf("ClearanceMap = %v, want %v", got, want)
+	}
+	// so a 2x2 unit cannot get from one room to the other
+	if path, _ := FindPathWithSize(g, Node{0, 0}, Node{3, 0}, 2); path != nil {
+		t.Errorf("2x2 unit squeezed through the doorway: %v", path)
+	}
+}
+
+func TestFindPathWithRadiusAvoidsNarrowGap(t *testing.T) {
+	// a wall down x=5 with a one-cell gap at y=1 and open rows below y=5
+	g := NewGrid(11, 10)
+	for y := 0; y <= 5; y++ {
+		if y != 1 {
+			g.Impassable[Node{5, y}] = true
+		}
+	}
+	start, goal := Node{1, 1}, Node{9, 1}
+
+	if path, cost := FindPathWithRadius(g, start, goal, 0); cost != 8 {
+		t.Errorf("radius 0: FindPathWithRadius = %v, %d; want through the gap at cost 8", path, cost)
+	}
+
+	// a unit three cells across goes round the end of the wall instead
+	path, cost := FindPathWithRadius(g, start, goal, 1)
+	if path == nil || cost <= 8 {
+		t.Fatalf("radius 1: FindPathWithRadius = %v, %d; want a detour dearer than 8", path, cost)
+	}
+	for _, n := range path {
+		for _, d := range Directions8() {
+			if g.Impassable[n.Add(d.X, d.Y)] {
+				t.Fatalf("radius 1: path %v passes within one cell of the wall at %v", path, n)
+			}
+		}
+	}
+	if err := g.checkPath(path, start, goal, cost); err != nil {
+		t.Errorf("radius 1: path %v: %v", path, err)
+	}
+
+	// the grid edge is not an obstacle, but the goal must keep clear of walls
+	if path, _ := FindPathWithRadius(g, start, Node{6, 0}, 1); path != nil {
+		t.Errorf("radius 1: got path %v to a goal beside the wall", path)
+	}
+	if path, _ := FindPathWithRadius(g, Node{0, 9}, Node{10, 9}, 1); path == nil {
+		t.Error("radius 1: found no path along the open bottom edge")
+	}
+}
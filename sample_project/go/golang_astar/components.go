@@ -0,0 +1,76 @@
+package golang_astar
+
+// Connected reports whether any sequence of moves leads from a to b,
+// found with a breadth-first flood fill that ignores costs. Barriers are
+// expensive but can be crossed, so only Impassable cells and the grid's
+// movement rules disconnect cells.
+func (g *Grid) Connected(a, b Node) bool {
+	if !g.IsValidPosition(a) || !g.IsValidPosition(b) {
+		return false
+	}
+	visited := map[Node]bool{a: true}
+	queue := []Node{a}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if n == b {
+			return true
+		}
+		for _, arc := range g.Neighbors(n) {
+			if !visited[arc.To] {
+				visited[arc.To] = true
+				queue = append(queue, arc.To)
+			}
+		}
+	}
+	return false
+}
+
+// ConnectedComponents labels each open cell with the id of the region it
+// belongs to and returns the labels along with the number of regions.
+// Barriers and Impassable cells belong to no region. Moves join cells in
+// both directions, so a one-way move still puts its ends in one region.
+// Ids count up from 0 in the order regions are met scanning rows from the
+// top.
+func (g *Grid) ConnectedComponents() (map[Node]int, int) {
+	links := make(map[Node][]Node)
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			n := Node{x, y}
+			if g.blocked(n) {
+				continue
+			}
+			for _, arc := range g.Neighbors(n) {
+				if !g.blocked(arc.To) {
+					links[n] = append(links[n], arc.To)
+					links[arc.To] = append(links[arc.To], n)
+				}
+			}
+		}
+	}
+
+	labels := make(map[Node]int)
+	count := 0
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			n := Node{x, y}
+			if _, done := labels[n]; done || g.blocked(n) {
+				continue
+			}
+			labels[n] = count
+			queue := []Node{n}
+			for len(queue) > 0 {
+				cur := queue[0]
+				queue = queue[1:]
+				for _, next := range links[cur] {
+					if _, done := labels[next]; !done {
+						labels[next] = count
+						queue = append(queue, next)
+					}
+				}
+			}
+			count++
+		}
+	}
+	return labels, count
+}
@@ -0,0 +1,17 @@
+package golang_astar
+
+import "context"
+
+// FindPathContext finds the shortest path between start and goal, giving up
+// with ctx.Err() once ctx is cancelled. When no path exists it returns a nil
+// path and a nil error; a start or goal outside the grid is reported with
+// ErrStartInvalid or ErrGoalInvalid.
+func FindPathContext(ctx context.Context, grid *Grid, start, goal Node) ([]Node, Cost, error) {
+	if !grid.IsValidPosition(start) {
+		return nil, 0, ErrStartInvalid
+	}
+	if !grid.IsValidPosition(goal) {
+		return nil, 0, ErrGoalInvalid
+	}
+	return search(grid, start, goal, searchOptions{h: defaultHeuristic(grid), ctx: ctx})
+}
@@ -0,0 +1,38 @@
+package golang_astar
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFindPathContext(t *testing.T) {
+	g := NewGrid(64, 64)
+	start, goal := Node{0, 0}, Node{63, 63}
+
+	path, cost, err := FindPathContext(context.Background(), g, start, goal)
+	if err != nil || path == nil || cost != 63 {
+		t.Errorf("FindPathContext = %v, %d, %v; want a path at cost 63", path, cost, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// an unreachable goal keeps the search going past the first check
+	g.Impassable[goal] = true
+	if _, _, err := FindPathContext(ctx, g, start, goal); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled: err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFindPathContextOutOfBounds(t *testing.T) {
+	g := NewGrid(5, 5)
+	ctx := context.Background()
+	for _, n := range []Node{{-1, 2}, {2, -1}, {5, 2}, {2, 5}} {
+		if _, _, err := FindPathContext(ctx, g, n, Node{2, 2}); err != ErrStartInvalid {
+			t.Errorf("start %v: err = %v, want %v", n, err, ErrStartInvalid)
+		}
+		if _, _, err := FindPathContext(ctx, g, Node{2, 2}, n); err != ErrGoalInvalid {
+			t.Errorf("goal %v: err = %v, want %v", n, err, ErrGoalInvalid)
+		}
+	}
+}
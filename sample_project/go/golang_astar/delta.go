@@ -0,0 +1,41 @@
+package golang_astar
+
+// GridDelta is a change to a grid's barriers, small enough to stream to
+// clients that hold a copy of the grid
+type GridDelta struct {
+	Added   []Node
+	Removed []Node
+}
+
+// IsEmpty reports whether d changes nothing
+func (d GridDelta) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// ApplyDelta removes the barriers d removes and then adds the ones it adds,
+// through ClearBarrier and SetBarrier so the neighbor cache stays current.
+// Cells outside the grid are ignored.
+func (g *Grid) ApplyDelta(d GridDelta) {
+	for _, n := range d.Removed {
+		g.ClearBarrier(n)
+	}
+	g.SetBarriers(d.Added)
+}
+
+// Diff returns the delta that turns g's barriers into other's, listing
+// cells ordered by X and then by Y. Applying it to a copy of g reproduces
+// other's barriers; other fields, such as weights, are not compared.
+func (g *Grid) Diff(other *Grid) GridDelta {
+	var d GridDelta
+	for _, n := range other.barrierList() {
+		if !g.IsBarrier(n) {
+			d.Added = append(d.Added, n)
+		}
+	}
+	for _, n := range g.barrierList() {
+		if !other.IsBarrier(n) {
+			d.Removed = append(d.Removed, n)
+		}
+	}
+	return d
+}
@@ -0,0 +1,33 @@
+package golang_astar
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestApplyDiff(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		g := NewRandomGrid(30, 30, 0.3, seed)
+		g.Precompute()
+		other := NewRandomGrid(30, 30, 0.3, seed+100)
+
+		d := g.Diff(other)
+		if d.IsEmpty() {
+			t.Fatalf("seed %d: Diff of two different grids is empty", seed)
+		}
+		g.ApplyDelta(d)
+		if got, want := g.barrierList(), other.barrierList(); !slices.Equal(got, want) {
+			t.Fatalf("seed %d: barriers after ApplyDelta = %v, want %v", seed, got, want)
+		}
+		if d := g.Diff(other); !d.IsEmpty() {
+			t.Errorf("seed %d: Diff after ApplyDelta = %+v, want empty", seed, d)
+		}
+
+		// the neighbor cache followed the changes
+		start, goal := Node{0, 0}, Node{29, 29}
+		_, want := FindPath(other, start, goal)
+		if path, cost := FindPath(g, start, goal); cost != want {
+			t.Errorf("seed %d: FindPath = %v, %d; want cost %d", seed, path, cost, want)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package golang_astar
+
+// Dijkstra finds the shortest path between start and goal without any
+// heuristic guidance. It expands more nodes than FindPath, but stays optimal
+// on grids where no admissible heuristic is known, such as ones with
+// zero-weight terrain.
+func Dijkstra(grid *Grid, start, goal Node) ([]Node, Cost) {
+	return FindPathWithHeuristic(grid, start, goal, nil)
+}
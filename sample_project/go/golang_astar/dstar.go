@@ -0,0 +1,215 @@
+package golang_astar
+
+import "container/heap"
+
+// dstarEntry is a node waiting in the D* Lite priority queue
+type dstarEntry struct {
+	pos   Node
+	key   [2]Cost
+	index int // for heap.Interface
+}
+
+// dstarQueue implements heap.Interface ordered by key
+type dstarQueue []*dstarEntry
+
+func (q dstarQueue) Len() int           { return len(q) }
+func (q dstarQueue) Less(i, j int) bool { return keyLess(q[i].key, q[j].key) }
+func (q dstarQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *dstarQueue) Push(x interface{}) {
+	item := x.(*dstarEntry)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+func (q *dstarQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*q = old[0 : n-1]
+	return item
+}
+
+// keyLess compares D* Lite keys lexicographically
+func keyLess(a, b [2]Cost) bool {
+	return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+}
+
+// DStarLite plans a path from start to goal and repairs it cheaply as cells
+// of the grid change, instead of searching from scratch every time. It
+// searches backward from the goal, so after a change only the part of the
+// search the change affects is redone.
+type DStarLite struct {
+	grid        *Grid
+	start, goal Node
+	last        Node // start when km was last updated
+	km          Cost // heuristic offset accumulated as start moves
+	h           func(a, b Node) Cost
+
+	g, rhs map[Node]Cost // missing entries are MaxCost
+	open   dstarQueue
+	queued map[Node]*dstarEntry
+}
+
+// Init prepares to plan from start to goal on grid, discarding any
+// previous plan
+func (d *DStarLite) Init(grid *Grid, start, goal Node) {
+	*d = DStarLite{
+		grid:   grid,
+		start:  start,
+		goal:   goal,
+		last:   start,
+		h:      defaultHeuristic(grid),
+		g:      make(map[Node]Cost),
+		rhs:    map[Node]Cost{goal: 0},
+		queued: make(map[Node]*dstarEntry),
+	}
+	d.push(goal)
+}
+
+func (d *DStarLite) gOf(n Node) Cost {
+	if v, ok := d.g[n]; ok {
+		return v
+	}
+	return MaxCost
+}
+
+func (d *DStarLite) rhsOf(n Node) Cost {
+	if v, ok := d.rhs[n]; ok {
+		return v
+	}
+	return MaxCost
+}
+
+// key computes the queue priority of n
+func (d *DStarLite) key(n Node) [2]Cost {
+	m := d.gOf(n)
+	if r := d.rhsOf(n); r < m {
+		m = r
+	}
+	return [2]Cost{addCost(addCost(m, d.h(d.start, n)), d.km), m}
+}
+
+// push queues n, or updates its priority if it is already queued
+func (d *DStarLite) push(n Node) {
+	if e, ok := d.queued[n]; ok {
+		e.key = d.key(n)
+		heap.Fix(&d.open, e.index)
+		return
+	}
+	e := &dstarEntry{pos: n, key: d.key(n)}
+	d.queued[n] = e
+	heap.Push(&d.open, e)
+}
+
+// remove drops n from the queue if it is there
+func (d *DStarLite) remove(n Node) {
+	if e, ok := d.queued[n]; ok {
+		heap.Remove(&d.open, e.index)
+		delete(d.queued, n)
+	}
+}
+
+// updateVertex recomputes rhs(n) from its successors and queues n if it is
+// now inconsistent
+func (d *DStarLite) updateVertex(n Node) {
+	if n != d.goal {
+		best := MaxCost
+		for _, arc := range d.grid.Neighbors(n) {
+			if c := addCost(arc.Cost, d.gOf(arc.To)); c < best {
+				best = c
+			}
+		}
+		d.rhs[n] = best
+	}
+	if d.gOf(n) != d.rhsOf(n) {
+		d.push(n)
+	} else {
+		d.remove(n)
+	}
+}
+
+// ComputeShortestPath brings the plan up to date with the grid
+func (d *DStarLite) ComputeShortestPath() {
+	for d.open.Len() > 0 {
+		top := d.open[0]
+		if !keyLess(top.key, d.key(d.start)) && d.rhsOf(d.start) <= d.gOf(d.start) {
+			return
+		}
+
+		u := top.pos
+		if newKey := d.key(u); keyLess(top.key, newKey) {
+			top.key = newKey
+			heap.Fix(&d.open, top.index)
+			continue
+		}
+
+		d.remove(u)
+		if d.gOf(u) > d.rhsOf(u) {
+			d.g[u] = d.rhsOf(u)
+		} else {
+			d.g[u] = MaxCost
+			d.updateVertex(u)
+		}
+		for _, arc := range d.grid.predecessors(u) {
+			d.updateVertex(arc.To)
+		}
+	}
+}
+
+// UpdateCell makes n a barrier or clears it, then marks every node whose
+// moves may have changed so the next ComputeShortestPath repairs the plan:
+// the cells around n, further out with a WallProximityPenalty and across
+// the edges of a wrapping grid, and the sources of portals into n.
+func (d *DStarLite) UpdateCell(n Node, newBarrier bool) {
+	if newBarrier {
+		d.grid.SetBarrier(n)
+	} else {
+		d.grid.ClearBarrier(n)
+	}
+
+	d.km = addCost(d.km, d.h(d.last, d.start))
+	d.last = d.start
+	for _, u := range d.grid.dependents(n) {
+		d.updateVertex(u)
+	}
+	// portals into n are not among its dependents
+	for _, arc := range d.grid.predecessors(n) {
+		d.updateVertex(arc.To)
+	}
+}
+
+// MoveStart moves the start of the plan, for an agent following the path
+func (d *DStarLite) MoveStart(n Node) {
+	d.start = n
+}
+
+// Path returns the current plan from start to goal and its cost, or nil if
+// the goal is unreachable. Call ComputeShortestPath first.
+func (d *DStarLite) Path() ([]Node, Cost) {
+	// the search may stop with start's g stale but its rhs correct
+	cost := d.rhsOf(d.start)
+	if cost == MaxCost {
+		return nil, 0 // No path found
+	}
+
+	path := []Node{d.start}
+	for n := d.start; n != d.goal; {
+		next, best := n, MaxCost
+		for _, arc := range d.grid.Neighbors(n) {
+			if c := addCost(arc.Cost, d.gOf(arc.To)); c < best {
+				next, best = arc.To, c
+			}
+		}
+		if next == n || len(path) > len(d.g) {
+			return nil, 0 // plan is out of date
+		}
+		path = append(path, next)
+		n = next
+	}
+	return path, cost
+}
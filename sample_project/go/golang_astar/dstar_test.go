@@ -0,0 +1,84 @@
+package golang_astar
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDStarLiteReplansAroundNewWall(t *testing.T) {
+	g := NewGrid(40, 40)
+	for y := 0; y < 40; y++ {
+		if y != 20 {
+			g.Impassable[Node{20, y}] = true
+		}
+	}
+	calls := 0
+	g.NeighborFunc = func(n Node) []Arc {
+		calls++
+		return g.GetNeighbors(n)
+	}
+	start, goal := Node{0, 0}, Node{39, 39}
+
+	var d DStarLite
+	d.Init(g, start, goal)
+	d.ComputeShortestPath()
+	path, _ := d.Path()
+
+	// drop a wall onto the path just ahead of the agent
+	calls = 0
+	blocked := path[2]
+	d.UpdateCell(blocked, true)
+	d.ComputeShortestPath()
+	path, cost := d.Path()
+	replanned := calls
+
+	calls = 0
+	var fresh DStarLite
+	fresh.Init(g, start, goal)
+	fresh.ComputeShortestPath()
+	planned := calls
+
+	if _, want := Dijkstra(g, start, goal); cost != want {
+		t.Fatalf("replanned cost = %d, want %d", cost, want)
+	}
+	for _, n := range path {
+		if n == blocked {
+			t.Fatalf("replanned path %v still crosses %v", path, blocked)
+		}
+	}
+	if replanned >= planned {
+		t.Errorf("replanning looked up %d neighbor lists, planning from scratch %d", replanned, planned)
+	}
+}
+
+func TestDStarLiteMatchesDijkstra(t *testing.T) {
+	r := rand.New(rand.NewSource(37))
+	for trial := 0; trial < 100; trial++ {
+		g := NewRandomGrid(3+r.Intn(12), 3+r.Intn(12), 0.2, r.Int63())
+		g.AllowCornerCutting = r.Intn(2) == 0
+		if r.Intn(2) == 0 {
+			g.WallProximityPenalty = 3
+		}
+		start := Node{r.Intn(g.Width), r.Intn(g.Height)}
+		goal := Node{r.Intn(g.Width), r.Intn(g.Height)}
+
+		var d DStarLite
+		d.Init(g, start, goal)
+		for step := 0; step < 15; step++ {
+			d.ComputeShortestPath()
+			path, cost := d.Path()
+			if _, want := Dijkstra(g, d.start, goal); cost != want {
+				t.Fatalf("trial %d, step %d: cost %d, want %d", trial, step, cost, want)
+			}
+			if walked, err := g.PathCost(path); err != nil || walked != cost {
+				t.Fatalf("trial %d, step %d: path %v walks for %d (%v), reported %d",
+					trial, step, path, walked, err, cost)
+			}
+			if len(path) > 1 && r.Intn(3) == 0 {
+				d.MoveStart(path[1])
+			}
+			n := Node{r.Intn(g.Width), r.Intn(g.Height)}
+			d.UpdateCell(n, !g.IsBarrier(n))
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package golang_astar
+
+import "errors"
+
+var (
+	// ErrNoPath is returned when the goal cannot be reached from the start
+	ErrNoPath = errors.New("astar: no path found")
+	// ErrStartInvalid is returned when the start lies outside the grid
+	ErrStartInvalid = errors.New("astar: start is outside the grid")
+	// ErrGoalInvalid is returned when the goal lies outside the grid
+	ErrGoalInvalid = errors.New("astar: goal is outside the grid")
+)
+
+// FindPathE finds the shortest path between start and goal, reporting why
+// no path was returned instead of a bare nil
+func FindPathE(grid *Grid, start, goal Node) ([]Node, Cost, error) {
+	if !grid.IsValidPosition(start) {
+		return nil, 0, ErrStartInvalid
+	}
+	if !grid.IsValidPosition(goal) {
+		return nil, 0, ErrGoalInvalid
+	}
+	path, cost := FindPath(grid, start, goal)
+	if path == nil {
+		return nil, 0, ErrNoPath
+	}
+	return path, cost, nil
+}
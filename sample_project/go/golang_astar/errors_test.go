@@ -0,0 +1,36 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathOutOfBounds(t *testing.T) {
+	g := NewGrid(5, 4)
+	inside := Node{2, 2}
+	tests := []struct {
+		name string
+		n    Node
+	}{
+		{"negative x", Node{-1, 2}},
+		{"negative y", Node{2, -3}},
+		{"x past width", Node{5, 2}},
+		{"y past height", Node{2, 4}},
+	}
+	for _, tt := range tests {
+		if path, cost := FindPath(g, tt.n, inside); path != nil || cost != 0 {
+			t.Errorf("%s start: FindPath = %v, %d; want nil", tt.name, path, cost)
+		}
+		if path, cost := FindPath(g, inside, tt.n); path != nil || cost != 0 {
+			t.Errorf("%s goal: FindPath = %v, %d; want nil", tt.name, path, cost)
+		}
+		if _, _, err := FindPathE(g, tt.n, inside); err != ErrStartInvalid {
+			t.Errorf("%s start: FindPathE error = %v, want %v", tt.name, err, ErrStartInvalid)
+		}
+		if _, _, err := FindPathE(g, inside, tt.n); err != ErrGoalInvalid {
+			t.Errorf("%s goal: FindPathE error = %v, want %v", tt.name, err, ErrGoalInvalid)
+		}
+	}
+
+	g.Impassable[inside] = true
+	if _, _, err := FindPathE(g, Node{0, 0}, inside); err != ErrNoPath {
+		t.Errorf("walled-off goal: FindPathE error = %v, want %v", err, ErrNoPath)
+	}
+}
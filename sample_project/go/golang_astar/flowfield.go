@@ -0,0 +1,83 @@
+package golang_astar
+
+// FlowField returns the cost of the cheapest path from every cell that can
+// reach goal to goal, found with a single Dijkstra search run backward from
+// goal. Many units heading for the same goal can share one field.
+// Impassable cells, which no unit can step onto, are left out, so the field
+// is empty if goal is impassable or lies outside the grid.
+func (g *Grid) FlowField(goal Node) map[Node]Cost {
+	if !g.IsValidPosition(goal) || g.Impassable[goal] {
+		return make(map[Node]Cost)
+	}
+	var s Searcher
+	s.search(g, goal, goal, searchOptions{
+		isGoal: never,
+		successors: func(current *searchNode) []Arc {
+			return g.predecessors(current.pos)
+		},
+	})
+	field := s.settled()
+	for n := range g.Impassable {
+		delete(field, n)
+	}
+	return field
+}
+
+// FollowFlowField walks from start downhill through field, always stepping
+// to the adjacent cell with the lowest cost, until it reaches a cell with
+// cost 0. It only looks at the field, so it neither follows portals nor
+// knows which diagonal moves the grid forbids; Grid.FollowFlowField keeps
+// to the grid's own moves. It returns nil if start is not in the field or
+// the walk gets stuck.
+func FollowFlowField(field map[Node]Cost, start Node) []Node {
+	cost, ok := field[start]
+	if !ok {
+		return nil
+	}
+
+	path := []Node{start}
+	for n := start; cost > 0; {
+		next, nextCost := n, cost
+		for _, d := range directions8 {
+			if c, ok := field[n.Add(d.X, d.Y)]; ok && c < nextCost {
+				next, nextCost = n.Add(d.X, d.Y), c
+			}
+		}
+		if next == n {
+			return nil // stuck in a local minimum
+		}
+		path = append(path, next)
+		n, cost = next, nextCost
+	}
+	return path
+}
+
+// FollowFlowField walks from start to the goal of field, a FlowField of g,
+// always taking the move out of the current cell that minimizes the move's
+// cost plus the field's cost at its end, so it follows the grid's own
+// moves, portals included. The bool reports whether a cell of cost 0 was
+// reached; when start is not in the field, or the field no longer matches
+// the grid and the walk gets stuck, it returns nil and false.
+func (g *Grid) FollowFlowField(field map[Node]Cost, start Node) ([]Node, bool) {
+	cost, ok := field[start]
+	if !ok {
+		return nil, false
+	}
+
+	path := []Node{start}
+	for n := start; cost > 0; {
+		next, best := n, MaxCost
+		for _, arc := range g.Neighbors(n) {
+			if c, ok := field[arc.To]; ok && addCost(arc.Cost, c) < best {
+				next, best = arc.To, addCost(arc.Cost, c)
+			}
+		}
+		// a walk longer than the field has cells is going round in circles
+		if next == n || best > cost || len(path) > len(field) {
+			return nil, false
+		}
+		path = append(path, next)
+		n, cost = next, field[next]
+	}
+	return path, true
+}
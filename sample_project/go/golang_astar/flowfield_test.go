@@ -0,0 +1,91 @@
+package golang_astar
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFollowFlowField(t *testing.T) {
+	r := rand.New(rand.NewSource(38))
+	for trial := 0; trial < 50; trial++ {
+		g := NewRandomGrid(2+r.Intn(10), 2+r.Intn(10), 0.2, r.Int63())
+		if r.Intn(2) == 0 {
+			g.Connectivity = 4
+		}
+		for i := 0; i < 3; i++ {
+			n := Node{r.Intn(g.Width), r.Intn(g.Height)}
+			g.Weights[n] = Cost(1 + r.Intn(5))
+			g.ExitCosts[n] = Cost(r.Intn(3))
+		}
+		if err := g.AddPortal(Node{0, 0}, Node{g.Width - 1, g.Height - 1}, 1); err != nil {
+			t.Fatal(err)
+		}
+		goal := Node{r.Intn(g.Width), r.Intn(g.Height)}
+		field := g.FlowField(goal)
+
+		for start, want := range field {
+			path, ok := g.FollowFlowField(field, start)
+			if !ok || path[len(path)-1] != goal {
+				t.Fatalf("trial %d: walk from %v = %v, %t; want it to reach %v", trial, start, path, ok, goal)
+			}
+			if cost, err := g.PathCost(path); err != nil || cost != want {
+				t.Fatalf("trial %d: walk %v costs %d (%v), field says %d", trial, path, cost, err, want)
+			}
+		}
+	}
+}
+
+func TestFollowFlowFieldStuck(t *testing.T) {
+	g := NewGrid(5, 1)
+	g.Connectivity = 4
+	field := g.FlowField(Node{4, 0})
+
+	if path, ok := g.FollowFlowField(field, Node{-1, 0}); ok || path != nil {
+		t.Errorf("start outside the field: got %v, %t", path, ok)
+	}
+
+	// a wall dropped after the field was built leaves the walk stranded
+	g.Impassable[Node{2, 0}] = true
+	if path, ok := g.FollowFlowField(field, Node{0, 0}); ok || path != nil {
+		t.Errorf("stale field: got %v, %t; want nil, false", path, ok)
+	}
+}
+
+func TestFollowFlowFieldOnField(t *testing.T) {
+	g := NewGrid(20, 20)
+	for y := 0; y < 16; y++ {
+		g.Impassable[Node{10, y}] = true
+	}
+	goal := Node{15, 5}
+	field := g.FlowField(goal)
+
+	// every move costs one step and no two walls meet at a corner, so going
+	// downhill through the field is a shortest path
+	for start, want := range field {
+		path := FollowFlowField(field, start)
+		if path == nil || path[len(path)-1] != goal {
+			t.Fatalf("walk from %v = %v; want it to reach %v", start, path, goal)
+		}
+		if cost, err := g.PathCost(path); err != nil || cost != want {
+			t.Fatalf("walk %v costs %d (%v), field says %d", path, cost, err, want)
+		}
+	}
+	if path := FollowFlowField(field, Node{-1, 0}); path != nil {
+		t.Errorf("start outside the field: got %v", path)
+	}
+}
+
+func TestFlowFieldLeavesOutImpassable(t *testing.T) {
+	g := NewGrid(5, 5)
+	g.Impassable[Node{2, 2}] = true
+	field := g.FlowField(Node{4, 4})
+	if _, ok := field[Node{2, 2}]; ok || len(field) != 24 {
+		t.Errorf("FlowField has %d cells, including (2,2): %t; want the 24 open ones", len(field), ok)
+	}
+
+	for _, goal := range []Node{{5, 0}, {2, 2}} {
+		if field := g.FlowField(goal); len(field) != 0 {
+			t.Errorf("FlowField(%v) = %v, want empty", goal, field)
+		}
+	}
+}
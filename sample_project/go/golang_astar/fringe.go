@@ -0,0 +1,87 @@
+package golang_astar
+
+import "container/list"
+
+// fringeEntry is what fringe search remembers about a node it has reached
+type fringeEntry struct {
+	g      Cost
+	parent Node
+	root   bool
+	elem   *list.Element // position in the fringe, nil once expanded
+}
+
+// FindPathFringe finds the shortest path between start and goal with
+// fringe search. Like IDA* it makes passes with a rising f threshold, but
+// it keeps the fringe of the previous pass in a list, so a pass picks up
+// where the last left off instead of starting over, and it never sorts its
+// nodes. That avoids FindPath's priority queue, which often makes it faster
+// on grids whose costs take few distinct values.
+func FindPathFringe(grid *Grid, start, goal Node) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+	h := defaultHeuristic(grid)
+
+	fringe := list.New()
+	cache := map[Node]*fringeEntry{start: {root: true}}
+	cache[start].elem = fringe.PushBack(start)
+
+	limit := h(start, goal)
+	for fringe.Len() > 0 {
+		next := MaxCost
+		for e := fringe.Front(); e != nil; {
+			n := e.Value.(Node)
+			entry := cache[n]
+			if f := addCost(entry.g, h(n, goal)); f > limit {
+				next = min(next, f)
+				e = e.Next()
+				continue
+			}
+			if n == goal {
+				return fringePath(cache, goal), entry.g
+			}
+
+			// children go straight after n so this pass visits them next;
+			// inserting in reverse keeps them in Neighbors order
+			arcs := grid.Neighbors(n)
+			for i := len(arcs) - 1; i >= 0; i-- {
+				arc := arcs[i]
+				g := addCost(entry.g, arc.Cost)
+				child := cache[arc.To]
+				if child == nil {
+					child = &fringeEntry{}
+					cache[arc.To] = child
+				} else if g >= child.g {
+					continue
+				}
+				if child.elem != nil {
+					fringe.Remove(child.elem)
+				}
+				child.g, child.parent, child.root = g, n, false
+				child.elem = fringe.InsertAfter(arc.To, e)
+			}
+
+			following := e.Next()
+			fringe.Remove(e)
+			entry.elem = nil
+			e = following
+		}
+		limit = next
+	}
+	return nil, 0
+}
+
+// fringePath follows the cached parents back from goal
+func fringePath(cache map[Node]*fringeEntry, goal Node) []Node {
+	var path []Node
+	for n := goal; ; n = cache[n].parent {
+		path = append(path, n)
+		if cache[n].root {
+			break
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
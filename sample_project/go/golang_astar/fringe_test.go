@@ -0,0 +1,51 @@
+package golang_astar
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFindPathFringeMatchesFindPath(t *testing.T) {
+	r := rand.New(rand.NewSource(74))
+	for i := 0; i < 200; i++ {
+		g := NewRandomGrid(2+r.Intn(20), 2+r.Intn(20), 0.3, r.Int63())
+		if r.Intn(2) == 0 {
+			g.Impassable, g.Barriers = g.Barriers, make(map[Node]bool)
+		}
+		if r.Intn(2) == 0 {
+			g.Connectivity = 4
+		}
+		start := Node{r.Intn(g.Width), r.Intn(g.Height)}
+		goal := Node{r.Intn(g.Width), r.Intn(g.Height)}
+
+		want, wantCost := FindPath(g, start, goal)
+		path, cost := FindPathFringe(g, start, goal)
+		if (path == nil) != (want == nil) || cost != wantCost {
+			t.Fatalf("grid %d, %v -> %v: got %v at %d, want %v at %d", i, start, goal, path, cost, want, wantCost)
+		}
+		if path == nil {
+			continue
+		}
+		if walked, err := g.PathCost(path); err != nil || walked != cost {
+			t.Fatalf("grid %d: path %v walks for %d (%v), reported %d", i, path, walked, err, cost)
+		}
+	}
+}
+
+func BenchmarkFindPathFringe(b *testing.B) {
+	searches := []struct {
+		name string
+		find func(grid *Grid, start, goal Node) ([]Node, Cost)
+	}{
+		{"astar", func(grid *Grid, start, goal Node) ([]Node, Cost) { return FindPath(grid, start, goal) }},
+		{"fringe", FindPathFringe},
+	}
+	g := NewRandomGrid(128, 128, 0.25, 74)
+	for _, s := range searches {
+		b.Run(s.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.find(g, Node{0, 0}, Node{127, 127})
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package golang_astar
+
+import "math/rand"
+
+// NewRandomGrid creates a grid where each cell is a barrier with probability
+// barrierDensity, drawn from a generator seeded with seed so the same
+// arguments always give the same grid. The corners (0,0) and
+// (width-1,height-1) are always left open for use as start and goal.
+func NewRandomGrid(width, height int, barrierDensity float64, seed int64) *Grid {
+	r := rand.New(rand.NewSource(seed))
+	grid := NewGrid(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if r.Float64() < barrierDensity {
+				grid.Barriers[Node{x, y}] = true
+			}
+		}
+	}
+	delete(grid.Barriers, Node{0, 0})
+	delete(grid.Barriers, Node{width - 1, height - 1})
+	return grid
+}
+
+// NewMazeGrid creates a perfect maze with a recursive backtracker seeded
+// with seed. Rooms sit on even coordinates and the walls around them are
+// Barriers, as with NewGridFromStrings, so there is exactly one route
+// between any two open cells that does not pass through a wall. Odd widths
+// and heights leave no dead border along the right and bottom edges.
+func NewMazeGrid(width, height int, seed int64) *Grid {
+	r := rand.New(rand.NewSource(seed))
+	grid := NewGrid(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			grid.Barriers[Node{x, y}] = true
+		}
+	}
+	if width <= 0 || height <= 0 {
+		return grid
+	}
+
+	delete(grid.Barriers, Node{0, 0})
+	stack := []Node{{0, 0}}
+	for len(stack) > 0 {
+		room := stack[len(stack)-1]
+		var next []Node
+		for _, d := range directions4 {
+			n := room.Add(2*d.X, 2*d.Y)
+			if grid.IsValidPosition(n) && grid.Barriers[n] {
+				next = append(next, n)
+			}
+		}
+		if len(next) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		n := next[r.Intn(len(next))]
+		delete(grid.Barriers, Node{(room.X + n.X) / 2, (room.Y + n.Y) / 2})
+		delete(grid.Barriers, n)
+		stack = append(stack, n)
+	}
+	return grid
+}
@@ -0,0 +1,106 @@
+package golang_astar
+
+import "container/heap"
+
+// ArcG is an Arc between nodes of any comparable type
+type ArcG[T comparable] struct {
+	To   T
+	Cost Cost
+}
+
+// searchNodeG is a searchNode for nodes of any comparable type
+type searchNodeG[T comparable] struct {
+	pos     T
+	parent  *searchNodeG[T]
+	g, h, f Cost
+	index   int // for heap.Interface
+}
+
+// nodeHeapG implements heap.Interface
+type nodeHeapG[T comparable] []*searchNodeG[T]
+
+func (h nodeHeapG[T]) Len() int           { return len(h) }
+func (h nodeHeapG[T]) Less(i, j int) bool { return h[i].f < h[j].f }
+func (h nodeHeapG[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *nodeHeapG[T]) Push(x interface{}) {
+	item := x.(*searchNodeG[T])
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+func (h *nodeHeapG[T]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[0 : n-1]
+	return item
+}
+
+// FindPathG finds the shortest path between start and goal over any node
+// type, such as 3D coordinates or string-keyed graph nodes. neighbors lists
+// the arcs out of a node and heuristic estimates the remaining cost; a nil
+// heuristic is treated as zero.
+func FindPathG[T comparable](start, goal T, neighbors func(T) []ArcG[T], heuristic func(a, b T) Cost) ([]T, Cost) {
+	if heuristic == nil {
+		heuristic = func(a, b T) Cost { return 0 }
+	}
+
+	openSet := &nodeHeapG[T]{}
+	startNode := &searchNodeG[T]{pos: start, h: heuristic(start, goal)}
+	startNode.f = startNode.h
+	heap.Push(openSet, startNode)
+
+	openIndex := map[T]*searchNodeG[T]{start: startNode}
+	closedSet := make(map[T]*searchNodeG[T])
+
+	for openSet.Len() > 0 {
+		current := heap.Pop(openSet).(*searchNodeG[T])
+		delete(openIndex, current.pos)
+
+		if current.pos == goal {
+			var path []T
+			for n := current; n != nil; n = n.parent {
+				path = append(path, n.pos)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path, current.g
+		}
+
+		closedSet[current.pos] = current
+
+		for _, arc := range neighbors(current.pos) {
+			if _, exists := closedSet[arc.To]; exists {
+				continue
+			}
+
+			g := addCost(current.g, arc.Cost)
+
+			neighbor := openIndex[arc.To]
+			if neighbor == nil {
+				neighbor = &searchNodeG[T]{
+					pos:    arc.To,
+					parent: current,
+					g:      g,
+					h:      heuristic(arc.To, goal),
+				}
+				neighbor.f = addCost(neighbor.g, neighbor.h)
+				heap.Push(openSet, neighbor)
+				openIndex[arc.To] = neighbor
+			} else if g < neighbor.g {
+				neighbor.parent = current
+				neighbor.g = g
+				neighbor.f = addCost(g, neighbor.h)
+				heap.Fix(openSet, neighbor.index)
+			}
+		}
+	}
+
+	return nil, 0 // No path found
+}
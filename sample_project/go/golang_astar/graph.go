@@ -0,0 +1,32 @@
+package golang_astar
+
+// Graph is anything FindPath can search: a set of nodes joined by weighted,
+// directed arcs. *Grid is a Graph, but so is any other network, such as
+// roads, whose nodes can be keyed by a Node.
+//
+// Since arcs are directed, going from a to b may cost more or less than
+// coming back, as with a cheap slope down and a steep climb up. The search
+// only ever relaxes arcs in their own direction, so this needs no special
+// handling, but a heuristic h(a, goal) stays admissible only if it never
+// exceeds the cheapest cost of going forward from a to goal. Under
+// asymmetric costs that means the cheap direction: the distance heuristics
+// FindPath uses hold only while every move, downhill ones included, costs
+// at least one step, or MinEdgeCost on a Grid that sets it.
+type Graph interface {
+	// Neighbors returns the arcs leaving n
+	Neighbors(n Node) []Arc
+}
+
+var _ Graph = (*Grid)(nil)
+
+// bounded is implemented by graphs with a fixed extent, such as *Grid
+type bounded interface {
+	IsValidPosition(n Node) bool
+}
+
+// outOfBounds reports whether start or goal lies outside graph, for graphs
+// that know their extent
+func outOfBounds(graph Graph, start, goal Node) bool {
+	b, ok := graph.(bounded)
+	return ok && (!b.IsValidPosition(start) || !b.IsValidPosition(goal))
+}
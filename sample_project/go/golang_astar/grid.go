@@ -1,48 +1,530 @@
 package golang_astar
 
-// Grid represents the search space with barriers
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Grid represents the search space with barriers. Barriers are expensive
+// to enter but still traversable; Impassable cells are never entered.
+// Weights multiply the cost of entering a cell and default to 1.
 type Grid struct {
-	Width    int
-	Height   int
-	Barriers map[Node]bool
+	Width      int
+	Height     int
+	Barriers   map[Node]bool
+	Impassable map[Node]bool
+	Weights    map[Node]Cost
+
+	// ExitCosts adds a fixed cost to every adjacent move out of a cell, on
+	// top of the cost of entering the next one, for terrain such as mud that
+	// is slow to climb out of
+	ExitCosts map[Node]Cost
+
+	// Danger adds a fixed cost to every adjacent move into a cell, on top of
+	// its terrain, for overlays such as enemy influence. Kept apart from
+	// Weights, it can be swapped for each situation without touching the
+	// terrain; call InvalidateNeighbors after changing it on a precomputed
+	// grid.
+	Danger map[Node]Cost
+
+	// WallProximityPenalty is added to the cost of entering any cell next to
+	// a barrier or impassable cell, diagonals included, so that among paths
+	// of otherwise equal cost the search keeps to open space instead of
+	// hugging walls. Being added, it never makes the distance heuristics
+	// overestimate.
+	WallProximityPenalty Cost
+
+	// Wrap joins opposite edges of the grid into a torus, so moves off one
+	// edge come back on the other, as in Pac-Man. WrapX and WrapY wrap only
+	// the left and right or top and bottom edges, making a cylinder such as
+	// a world map. Positions are still numbered from 0 to Width-1 and
+	// Height-1. Line of sight and the any-angle searches ignore wrapping.
+	Wrap  bool
+	WrapX bool
+	WrapY bool
+
+	// Connectivity is the number of neighbors a cell has: 4 allows only
+	// orthogonal moves, 8 (or zero) also allows diagonal moves
+	Connectivity int
+
+	// OrthogonalCost and DiagonalCost are the costs of a single step along
+	// an axis and along a diagonal. Zero means 1. Setting them to 10 and 14
+	// approximates Euclidean distances in integer costs. They are kept apart
+	// from terrain: Weights and the barrier penalty multiply the step cost.
+	OrthogonalCost Cost
+	DiagonalCost   Cost
+
+	// MinEdgeCost, when above 1, scales the distance heuristic FindPath and
+	// its variants use, so it estimates costs rather than steps and the
+	// search expands fewer nodes. The paths stay shortest only if every move
+	// on the grid costs at least MinEdgeCost.
+	MinEdgeCost Cost
+
+	// AllowCornerCutting lets diagonal moves squeeze past barriers. When
+	// false a diagonal move needs both orthogonal cells it passes to be
+	// free of barriers. NewGrid enables it.
+	AllowCornerCutting bool
+
+	// DiagonalPolicy, when set, decides which diagonal moves barriers allow
+	// in place of AllowCornerCutting
+	DiagonalPolicy DiagonalPolicy
+
+	// NeighborFunc, when set, replaces GetNeighbors as the source of moves
+	// the searches consider, for movement rules such as one-way cells
+	NeighborFunc func(n Node) []Arc
+
+	// AllowedDirections, when set, limits the adjacent moves out of n to the
+	// returned steps, such as Node{1, 0} for a conveyor belt running east.
+	// Portals are not affected.
+	AllowedDirections func(n Node) []Node
+
+	// Portals adds extra arcs out of a cell, such as teleports, on top of
+	// the adjacent moves. A cheap portal makes the distance heuristics
+	// overestimate, so search grids with portals using Dijkstra.
+	Portals map[Node][]Arc
+
+	// barrierBits replaces Barriers on grids made by NewDenseGrid
+	barrierBits []uint64
+
+	// neighbors caches GetNeighbors for every cell once Precompute is called
+	neighbors map[Node][]Arc
 }
 
 // NewGrid creates a new grid with the given dimensions
 func NewGrid(width, height int) *Grid {
 	return &Grid{
-		Width:    width,
-		Height:   height,
-		Barriers: make(map[Node]bool),
+		Width:      width,
+		Height:     height,
+		Barriers:   make(map[Node]bool),
+		Impassable: make(map[Node]bool),
+		Weights:    make(map[Node]Cost),
+		ExitCosts:  make(map[Node]Cost),
+		Danger:     make(map[Node]Cost),
+		Portals:    make(map[Node][]Arc),
+
+		AllowCornerCutting: true,
 	}
 }
 
+// AddPortal connects from to to with a one-way arc of the given cost
+func (g *Grid) AddPortal(from, to Node, cost Cost) error {
+	if !g.IsValidPosition(from) {
+		return fmt.Errorf("astar: portal source %v is outside the grid", from)
+	}
+	if !g.IsValidPosition(to) {
+		return fmt.Errorf("astar: portal destination %v is outside the grid", to)
+	}
+	if g.Portals == nil {
+		g.Portals = make(map[Node][]Arc)
+	}
+	g.Portals[from] = append(g.Portals[from], Arc{to, cost})
+	return nil
+}
+
 // IsValidPosition checks if a position is within grid bounds
 func (g *Grid) IsValidPosition(n Node) bool {
 	return n.X >= 0 && n.X < g.Width && n.Y >= 0 && n.Y < g.Height
 }
 
-// GetNeighbors returns valid neighboring nodes
+// wrapsX and wrapsY report whether the grid wraps around horizontally and
+// vertically
+func (g *Grid) wrapsX() bool { return (g.Wrap || g.WrapX) && g.Width > 0 }
+func (g *Grid) wrapsY() bool { return (g.Wrap || g.WrapY) && g.Height > 0 }
+
+// wrap brings n back onto the grid across the edges it wraps around
+func (g *Grid) wrap(n Node) Node {
+	if g.wrapsX() {
+		n.X = ((n.X % g.Width) + g.Width) % g.Width
+	}
+	if g.wrapsY() {
+		n.Y = ((n.Y % g.Height) + g.Height) % g.Height
+	}
+	return n
+}
+
+// nearestCopy returns the copy of b, repeated across the edges the grid
+// wraps around, that lies closest to a, so distances measured to it take
+// the short way round
+func (g *Grid) nearestCopy(a, b Node) Node {
+	if d := b.X - a.X; g.wrapsX() && 2*abs(d) > g.Width {
+		b.X -= sign(d) * g.Width
+	}
+	if d := b.Y - a.Y; g.wrapsY() && 2*abs(d) > g.Height {
+		b.Y -= sign(d) * g.Height
+	}
+	return b
+}
+
+// wrapHeuristic makes h measure the short way round on a wrapping grid
+func (g *Grid) wrapHeuristic(h func(a, b Node) Cost) func(a, b Node) Cost {
+	if !g.wrapsX() && !g.wrapsY() {
+		return h
+	}
+	return func(a, b Node) Cost {
+		return h(a, g.nearestCopy(a, b))
+	}
+}
+
+// blocked reports whether n is off the grid, a barrier or impassable
+func (g *Grid) blocked(n Node) bool {
+	return !g.IsValidPosition(n) || g.IsBarrier(n) || g.Impassable[n]
+}
+
+// stepCosts returns the effective orthogonal and diagonal step costs
+func (g *Grid) stepCosts() (ortho, diag Cost) {
+	ortho, diag = g.OrthogonalCost, g.DiagonalCost
+	if ortho == 0 {
+		ortho = 1
+	}
+	if diag == 0 {
+		diag = 1
+	}
+	return ortho, diag
+}
+
+// enterCost returns the multiplier applied to steps onto n
+func (g *Grid) enterCost(n Node) Cost {
+	cost := Cost(1)
+	if w, ok := g.Weights[n]; ok {
+		cost = w
+	}
+	if g.IsBarrier(n) {
+		cost = mulCost(cost, 100)
+	}
+	return cost
+}
+
+// DiagonalPolicy is a rule for diagonal moves past barriers and
+// impassable cells
+type DiagonalPolicy int
+
+const (
+	// DiagonalDefault follows AllowCornerCutting: DiagonalAlways when it is
+	// set and DiagonalOnlyWhenBothOpen otherwise
+	DiagonalDefault DiagonalPolicy = iota
+	// DiagonalAlways allows every diagonal move, even between two blocked
+	// cells
+	DiagonalAlways
+	// DiagonalNoCornerCutting forbids squeezing between two blocked cells
+	// but allows a diagonal move round the corner of a single one
+	DiagonalNoCornerCutting
+	// DiagonalOnlyWhenBothOpen allows a diagonal move only when both cells
+	// it passes are free
+	DiagonalOnlyWhenBothOpen
+)
+
+// String returns the name of the policy
+func (p DiagonalPolicy) String() string {
+	switch p {
+	case DiagonalDefault:
+		return "DiagonalDefault"
+	case DiagonalAlways:
+		return "DiagonalAlways"
+	case DiagonalNoCornerCutting:
+		return "DiagonalNoCornerCutting"
+	case DiagonalOnlyWhenBothOpen:
+		return "DiagonalOnlyWhenBothOpen"
+	}
+	return fmt.Sprintf("DiagonalPolicy(%d)", int(p))
+}
+
+// diagonalAllowed reports whether the diagonal step d out of n passes the
+// grid's diagonal policy
+func (g *Grid) diagonalAllowed(n, d Node) bool {
+	policy := g.DiagonalPolicy
+	if policy == DiagonalDefault {
+		policy = DiagonalOnlyWhenBothOpen
+		if g.AllowCornerCutting {
+			policy = DiagonalAlways
+		}
+	}
+	a, b := g.blocked(g.wrap(n.Add(d.X, 0))), g.blocked(g.wrap(n.Add(0, d.Y)))
+	switch policy {
+	case DiagonalNoCornerCutting:
+		return !a || !b
+	case DiagonalOnlyWhenBothOpen:
+		return !a && !b
+	}
+	return true
+}
+
+// nearWall reports whether a barrier or impassable cell touches n
+func (g *Grid) nearWall(n Node) bool {
+	for _, d := range directions8 {
+		if u := g.wrap(n.Add(d.X, d.Y)); g.IsValidPosition(u) && (g.IsBarrier(u) || g.Impassable[u]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Precompute caches the neighbors of every cell, so searches over a grid
+// that no longer changes skip recomputing them. SetBarrier and ClearBarrier
+// keep the cache up to date; after changing any other field directly, call
+// Precompute again or InvalidateNeighbors.
+func (g *Grid) Precompute() {
+	g.neighbors = nil
+	cache := make(map[Node][]Arc, g.Width*g.Height)
+	for x := 0; x < g.Width; x++ {
+		for y := 0; y < g.Height; y++ {
+			n := Node{x, y}
+			cache[n] = g.GetNeighbors(n)
+		}
+	}
+	g.neighbors = cache
+}
+
+// InvalidateNeighbors drops the cache built by Precompute
+func (g *Grid) InvalidateNeighbors() {
+	g.neighbors = nil
+}
+
+// SetBarrier makes n a barrier, updating the neighbor cache if there is
+// one. Nodes outside the grid are ignored.
+func (g *Grid) SetBarrier(n Node) {
+	if !g.IsValidPosition(n) {
+		return
+	}
+	switch {
+	case g.barrierBits != nil:
+		i := g.bitIndex(n)
+		g.barrierBits[i/64] |= 1 << (i % 64)
+	case g.Barriers == nil:
+		g.Barriers = map[Node]bool{n: true}
+	default:
+		g.Barriers[n] = true
+	}
+	g.refreshNeighbors(n)
+}
+
+// ClearBarrier removes the barrier at n, updating the neighbor cache if
+// there is one
+func (g *Grid) ClearBarrier(n Node) {
+	if g.barrierBits != nil {
+		if !g.IsValidPosition(n) {
+			return
+		}
+		i := g.bitIndex(n)
+		g.barrierBits[i/64] &^= 1 << (i % 64)
+	} else {
+		delete(g.Barriers, n)
+	}
+	g.refreshNeighbors(n)
+}
+
+// SetBarriers makes each of nodes a barrier, skipping any outside the grid
+func (g *Grid) SetBarriers(nodes []Node) {
+	for _, n := range nodes {
+		g.SetBarrier(n)
+	}
+}
+
+// ClearBarriers removes every barrier from the grid
+func (g *Grid) ClearBarriers() {
+	clear(g.Barriers)
+	clear(g.barrierBits)
+	if g.neighbors != nil {
+		g.Precompute()
+	}
+}
+
+// ToggleBarrier adds a barrier at n if there is none and removes it
+// otherwise
+func (g *Grid) ToggleBarrier(n Node) {
+	if g.IsBarrier(n) {
+		g.ClearBarrier(n)
+	} else {
+		g.SetBarrier(n)
+	}
+}
+
+// refreshNeighbors recomputes the cached neighbors of the cells whose
+// moves depend on n being a barrier
+func (g *Grid) refreshNeighbors(n Node) {
+	if g.neighbors == nil {
+		return
+	}
+	cache := g.neighbors
+	g.neighbors = nil
+	for _, u := range g.dependents(n) {
+		cache[u] = g.GetNeighbors(u)
+	}
+	g.neighbors = cache
+}
+
+// dependents lists the cells whose adjacent moves can change when n becomes
+// or stops being a barrier: those around n, whose diagonal moves pass its
+// corners, and n itself. With a WallProximityPenalty the cells around those
+// are included too, as moves into n's neighbors change cost.
+func (g *Grid) dependents(n Node) []Node {
+	r := 1
+	if g.WallProximityPenalty != 0 {
+		r = 2
+	}
+	cells := make([]Node, 0, (2*r+1)*(2*r+1))
+	for dx := -r; dx <= r; dx++ {
+		for dy := -r; dy <= r; dy++ {
+			// on a small wrapping grid several offsets land on one cell
+			if u := g.wrap(n.Add(dx, dy)); g.IsValidPosition(u) && !slices.Contains(cells, u) {
+				cells = append(cells, u)
+			}
+		}
+	}
+	return cells
+}
+
+// GetNeighbors returns valid neighboring nodes. Each arc is the move from n
+// onto the neighbor and costs what that direction costs: the neighbor's
+// weight and barrier penalty, n's exit cost and the neighbor's danger and
+// wall proximity penalty, so the arc back may cost something else.
 func (g *Grid) GetNeighbors(n Node) []Arc {
+	if arcs, ok := g.neighbors[n]; ok {
+		// cap the slice so appending to it never writes into the cache
+		return arcs[:len(arcs):len(arcs)]
+	}
+
 	neighbors := make([]Arc, 0, 8)
+	ortho, diag := g.stepCosts()
+	exit := g.ExitCosts[n]
+	var allowed []Node
+	if g.AllowedDirections != nil {
+		allowed = g.AllowedDirections(n)
+	}
 
-	// Check all 8 adjacent positions
-	for dx := -1; dx <= 1; dx++ {
-		for dy := -1; dy <= 1; dy++ {
-			if dx == 0 && dy == 0 {
-				continue
-			}
+	// Check all 8 adjacent positions, or only the 4 orthogonal ones
+	dirs := directions8
+	if g.Connectivity == 4 {
+		dirs = directions4
+	}
+	for _, d := range dirs {
+		if g.AllowedDirections != nil && !slices.Contains(allowed, d) {
+			continue
+		}
+
+		next := g.wrap(n.Add(d.X, d.Y))
+		if !g.IsValidPosition(next) || next == n || g.Impassable[next] {
+			continue
+		}
 
-			next := Node{n.X + dx, n.Y + dy}
-			if !g.IsValidPosition(next) {
+		cost := ortho
+		if d.X != 0 && d.Y != 0 {
+			if !g.diagonalAllowed(n, d) {
 				continue
 			}
+			cost = diag
+		}
+		cost = addCost(addCost(mulCost(cost, g.enterCost(next)), exit), g.Danger[next])
+		if g.WallProximityPenalty != 0 && g.nearWall(next) {
+			cost = addCost(cost, g.WallProximityPenalty)
+		}
+		neighbors = append(neighbors, Arc{next, cost})
+	}
 
-			cost := Cost(1)
-			if g.Barriers[next] {
-				cost = 100
-			}
-			neighbors = append(neighbors, Arc{next, cost})
+	for _, portal := range g.Portals[n] {
+		if g.IsValidPosition(portal.To) && !g.Impassable[portal.To] {
+			neighbors = append(neighbors, portal)
 		}
 	}
 	return neighbors
 }
+
+// LineOfSight walks a Bresenham line between a and b and reports whether
+// none of the cells along it, including both ends, are barriers or
+// impassable. The same cells are checked whichever end the line starts from.
+func (g *Grid) LineOfSight(a, b Node) bool {
+	return walkLine(a, b, func(n Node) bool { return !g.blocked(n) })
+}
+
+// lineCells lists the cells of the Bresenham line LineOfSight checks, in
+// order from a to b
+func lineCells(a, b Node) []Node {
+	var cells []Node
+	walkLine(a, b, func(n Node) bool {
+		cells = append(cells, n)
+		return true
+	})
+	if cells[0] != a {
+		slices.Reverse(cells)
+	}
+	return cells
+}
+
+// walkLine calls visit on each cell of the Bresenham line between a and b,
+// stopping early and returning false if visit does. The line is always
+// drawn from the same end, so it covers the same cells either way round.
+func walkLine(a, b Node, visit func(n Node) bool) bool {
+	if b.X < a.X || b.X == a.X && b.Y < a.Y {
+		a, b = b, a
+	}
+	dx, dy := abs(b.X-a.X), -abs(b.Y-a.Y)
+	sx, sy := sign(b.X-a.X), sign(b.Y-a.Y)
+	err := dx + dy
+	for n := a; ; {
+		if !visit(n) {
+			return false
+		}
+		if n == b {
+			return true
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			n.X += sx
+		}
+		if e2 <= dx {
+			err += dx
+			n.Y += sy
+		}
+	}
+}
+
+// Neighbors returns the moves out of n, honoring NeighborFunc. It makes
+// *Grid a Graph.
+func (g *Grid) Neighbors(n Node) []Arc {
+	if g.NeighborFunc != nil {
+		return g.NeighborFunc(n)
+	}
+	return g.GetNeighbors(n)
+}
+
+// predecessors returns arcs leading into n, with each arc's To set to the
+// node the move starts from and Cost set to the cost of moving onto n. Only
+// moves between adjacent cells and through Portals are found.
+func (g *Grid) predecessors(n Node) []Arc {
+	arcs := make([]Arc, 0, 8)
+	if g.NeighborFunc == nil {
+		for from, portals := range g.Portals {
+			for _, portal := range portals {
+				if portal.To == n && !isAdjacent(from, n) {
+					arcs = append(arcs, Arc{from, portal.Cost})
+				}
+			}
+		}
+		// keep the order independent of map iteration
+		sort.Slice(arcs, func(i, j int) bool {
+			if arcs[i].To != arcs[j].To {
+				return arcs[i].To.less(arcs[j].To)
+			}
+			return arcs[i].Cost < arcs[j].Cost
+		})
+	}
+	for _, d := range directions8 {
+		prev := g.wrap(n.Add(d.X, d.Y))
+		if !g.IsValidPosition(prev) {
+			continue
+		}
+		for _, arc := range g.Neighbors(prev) {
+			if arc.To == n {
+				arcs = append(arcs, Arc{prev, arc.Cost})
+			}
+		}
+	}
+	return arcs
+}
+
+// isAdjacent reports whether a and b are distinct and touch, diagonals
+// included
+func isAdjacent(a, b Node) bool {
+	return a != b && abs(a.X-b.X) <= 1 && abs(a.Y-b.Y) <= 1
+}
@@ -0,0 +1,92 @@
+package golang_astar
+
+import "fmt"
+
+// Node3D represents a position in a 3D grid
+type Node3D struct {
+	X, Y, Z int
+}
+
+// String provides a string representation of Node3D
+func (n Node3D) String() string {
+	return fmt.Sprintf("(%d,%d,%d)", n.X, n.Y, n.Z)
+}
+
+// Grid3D represents a voxel search space with barriers
+type Grid3D struct {
+	Width    int
+	Height   int
+	Depth    int
+	Barriers map[Node3D]bool
+
+	// Connectivity is the number of neighbors a cell has: 6 allows only
+	// moves along an axis, 26 (or zero) also allows diagonal moves
+	Connectivity int
+}
+
+// NewGrid3D creates a new 3D grid with the given dimensions
+func NewGrid3D(width, height, depth int) *Grid3D {
+	return &Grid3D{
+		Width:    width,
+		Height:   height,
+		Depth:    depth,
+		Barriers: make(map[Node3D]bool),
+	}
+}
+
+// IsValidPosition checks if a position is within grid bounds
+func (g *Grid3D) IsValidPosition(n Node3D) bool {
+	return n.X >= 0 && n.X < g.Width &&
+		n.Y >= 0 && n.Y < g.Height &&
+		n.Z >= 0 && n.Z < g.Depth
+}
+
+// GetNeighbors returns valid neighboring nodes
+func (g *Grid3D) GetNeighbors(n Node3D) []ArcG[Node3D] {
+	neighbors := make([]ArcG[Node3D], 0, 26)
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dz := -1; dz <= 1; dz++ {
+				moved := abs(dx) + abs(dy) + abs(dz)
+				if moved == 0 || g.Connectivity == 6 && moved > 1 {
+					continue
+				}
+
+				next := Node3D{n.X + dx, n.Y + dy, n.Z + dz}
+				if !g.IsValidPosition(next) {
+					continue
+				}
+
+				cost := Cost(1)
+				if g.Barriers[next] {
+					cost = 100
+				}
+				neighbors = append(neighbors, ArcG[Node3D]{next, cost})
+			}
+		}
+	}
+	return neighbors
+}
+
+// Heuristic3D estimates remaining cost to goal in a 3D grid
+func Heuristic3D(current, goal Node3D) Cost {
+	d := abs(current.X - goal.X)
+	if dy := abs(current.Y - goal.Y); dy > d {
+		d = dy
+	}
+	if dz := abs(current.Z - goal.Z); dz > d {
+		d = dz
+	}
+	return Cost(d)
+}
+
+// FindPath3D finds the shortest path between start and goal in a 3D grid
+func FindPath3D(grid *Grid3D, start, goal Node3D) ([]Node3D, Cost) {
+	h := Heuristic3D
+	if grid.Connectivity == 6 {
+		h = func(a, b Node3D) Cost {
+			return Cost(abs(a.X-b.X) + abs(a.Y-b.Y) + abs(a.Z-b.Z))
+		}
+	}
+	return FindPathG(start, goal, grid.GetNeighbors, h)
+}
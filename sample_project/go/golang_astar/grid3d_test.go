@@ -0,0 +1,36 @@
+package golang_astar
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindPath3DThroughWallPlane(t *testing.T) {
+	// a wall filling the plane x=1 of a 3x3x3 cube but for a hole in one corner
+	g := NewGrid3D(3, 3, 3)
+	hole := Node3D{1, 2, 2}
+	for y := 0; y < 3; y++ {
+		for z := 0; z < 3; z++ {
+			if n := (Node3D{1, y, z}); n != hole {
+				g.Barriers[n] = true
+			}
+		}
+	}
+	start, goal := Node3D{0, 0, 0}, Node3D{2, 0, 0}
+
+	tests := []struct {
+		connectivity int
+		cost         Cost
+	}{
+		{26, 4}, // two diagonal steps up to the hole and two back down
+		{6, 10}, // the same climb one axis at a time
+	}
+	for _, tt := range tests {
+		g.Connectivity = tt.connectivity
+		path, cost := FindPath3D(g, start, goal)
+		if cost != tt.cost || !slices.Contains(path, hole) {
+			t.Errorf("connectivity %d: FindPath3D = %v, %d; want cost %d through %v",
+				tt.connectivity, path, cost, tt.cost, hole)
+		}
+	}
+}
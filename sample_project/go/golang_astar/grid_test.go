@@ -0,0 +1,311 @@
+package golang_astar
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestFindPathConnectivity4(t *testing.T) {
+	g := NewGrid(5, 5)
+	g.Connectivity = 4
+	g.Barriers[Node{1, 0}] = true
+	g.Barriers[Node{1, 1}] = true
+	g.Barriers[Node{1, 2}] = true
+
+	// the wall forces the path down to row 3 and back up: 3 + 2 + 3 moves
+	start, goal := Node{0, 0}, Node{2, 0}
+	path, cost := FindPath(g, start, goal)
+	if cost != 8 || len(path) != 9 {
+		t.Fatalf("FindPath = %v, %d; want 9 nodes at cost 8", path, cost)
+	}
+	for i := 1; i < len(path); i++ {
+		if ManhattanHeuristic(path[i-1], path[i]) != 1 {
+			t.Fatalf("move %v -> %v is not orthogonal", path[i-1], path[i])
+		}
+	}
+
+	if _, cost := FindPathWithHeuristic(g, start, goal, ManhattanHeuristic); cost != 8 {
+		t.Errorf("FindPathWithHeuristic(ManhattanHeuristic) cost = %d, want 8", cost)
+	}
+}
+
+func TestFindPathEnclosedByImpassable(t *testing.T) {
+	g := NewGrid(5, 5)
+	goal := Node{2, 2}
+	for _, d := range Directions8() {
+		g.Impassable[goal.Add(d.X, d.Y)] = true
+	}
+	if path, cost := FindPath(g, Node{0, 0}, goal); path != nil {
+		t.Errorf("FindPath = %v, %d; want no path", path, cost)
+	}
+
+	// soft barriers in the same place are only expensive
+	g.Barriers, g.Impassable = g.Impassable, make(map[Node]bool)
+	if path, _ := FindPath(g, Node{0, 0}, goal); path == nil {
+		t.Error("FindPath through barriers found no path")
+	}
+}
+
+func TestFindPathAvoidsCostlyTerrain(t *testing.T) {
+	g := NewGrid(5, 3)
+	g.Connectivity = 4
+	for x := 1; x < 4; x++ {
+		g.Weights[Node{x, 0}] = 5 // mud along the straight line
+	}
+
+	// straight through the mud costs 5+5+5+1, around it 1+4+1
+	path, cost := FindPath(g, Node{0, 0}, Node{4, 0})
+	if cost != 6 {
+		t.Fatalf("FindPath = %v, %d; want cost 6", path, cost)
+	}
+	for _, n := range path {
+		if g.Weights[n] != 0 {
+			t.Errorf("path %v enters mud at %v", path, n)
+		}
+	}
+}
+
+func TestCornerCutting(t *testing.T) {
+	// an L-shaped wall round (1,1): the diagonal from (0,0) squeezes past
+	// its corner
+	g := NewGrid(3, 3)
+	g.Barriers[Node{1, 0}] = true
+	g.Barriers[Node{0, 1}] = true
+	start, goal := Node{0, 0}, Node{1, 1}
+
+	if path, cost := FindPath(g, start, goal); cost != 1 {
+		t.Errorf("with cutting: FindPath = %v, %d; want the diagonal at cost 1", path, cost)
+	}
+
+	g.AllowCornerCutting = false
+	for _, arc := range g.GetNeighbors(start) {
+		if arc.To == goal {
+			t.Fatalf("GetNeighbors(%v) includes the diagonal past the wall", start)
+		}
+	}
+	// the only ways left go through a barrier
+	if path, cost := FindPath(g, start, goal); cost != 101 {
+		t.Errorf("without cutting: FindPath = %v, %d; want cost 101", path, cost)
+	}
+}
+
+func TestPortalShortcut(t *testing.T) {
+	g := NewGrid(60, 60)
+	if err := g.AddPortal(Node{1, 1}, Node{50, 50}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddPortal(Node{1, 1}, Node{60, 0}, 0); err == nil {
+		t.Error("AddPortal accepted a destination outside the grid")
+	}
+
+	// one step onto the portal, then one step off it
+	path, cost := Dijkstra(g, Node{0, 0}, Node{51, 51})
+	if want := []Node{{0, 0}, {1, 1}, {50, 50}, {51, 51}}; cost != 2 || !slices.Equal(path, want) {
+		t.Errorf("Dijkstra = %v, %d; want %v at cost 2", path, cost, want)
+	}
+}
+
+func TestAllowedDirectionsOneWay(t *testing.T) {
+	g := NewGrid(5, 2)
+	g.Connectivity = 4
+	// a conveyor along the top row only carries units west
+	g.AllowedDirections = func(n Node) []Node {
+		if n.Y == 0 && n.X > 0 {
+			return []Node{West}
+		}
+		return Directions4()
+	}
+
+	if path, cost := FindPath(g, Node{4, 0}, Node{0, 0}); cost != 4 {
+		t.Errorf("with the belt: FindPath = %v, %d; want cost 4", path, cost)
+	}
+	// against it, units drop to the bottom row and climb back up at the end
+	if path, cost := FindPath(g, Node{0, 0}, Node{4, 0}); cost != 6 {
+		t.Errorf("against the belt: FindPath = %v, %d; want cost 6", path, cost)
+	}
+}
+
+func TestStepCostCombination(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(g *Grid)
+		to    Node
+		cost  Cost
+	}{
+		{"orthogonal", nil, Node{2, 1}, 3},
+		{"diagonal", nil, Node{2, 2}, 5},
+		{"weight", func(g *Grid) { g.Weights[Node{2, 1}] = 4 }, Node{2, 1}, 3 * 4},
+		{"barrier", func(g *Grid) { g.Barriers[Node{2, 1}] = true }, Node{2, 1}, 3 * 100},
+		{"weighted barrier", func(g *Grid) {
+			g.Weights[Node{2, 2}] = 2
+			g.Barriers[Node{2, 2}] = true
+		}, Node{2, 2}, 5 * 2 * 100},
+		{"exit cost", func(g *Grid) { g.ExitCosts[Node{1, 1}] = 7 }, Node{2, 2}, 5 + 7},
+		{"exit cost is not scaled", func(g *Grid) {
+			g.Weights[Node{2, 1}] = 4
+			g.ExitCosts[Node{1, 1}] = 7
+		}, Node{2, 1}, 3*4 + 7},
+		{"exit cost of the target is ignored", func(g *Grid) { g.ExitCosts[Node{2, 1}] = 7 }, Node{2, 1}, 3},
+	}
+	for _, tt := range tests {
+		g := NewGrid(4, 4)
+		g.OrthogonalCost, g.DiagonalCost = 3, 5
+		if tt.setup != nil {
+			tt.setup(g)
+		}
+		cost, ok := g.arcCost(Node{1, 1}, tt.to)
+		if !ok || cost != tt.cost {
+			t.Errorf("%s: move to %v costs %d (%t), want %d", tt.name, tt.to, cost, ok, tt.cost)
+		}
+	}
+}
+
+func TestDiagonalCostPrefersZigZagOnlyWhenCheaper(t *testing.T) {
+	tests := []struct {
+		diagonalCost Cost
+		cost         Cost
+		diagonals    int // -1 when either kind of path is as cheap
+	}{
+		{1, 3, 3},
+		{2, 6, -1}, // a diagonal costs as much as two orthogonal steps
+		{3, 6, 0},
+	}
+	for _, tt := range tests {
+		g := NewGrid(5, 5)
+		g.DiagonalCost = tt.diagonalCost
+		path, cost := FindPath(g, Node{0, 0}, Node{3, 3})
+		_, _, diagonals := g.PathMetrics(path)
+		if cost != tt.cost || tt.diagonals >= 0 && diagonals != tt.diagonals {
+			t.Errorf("diagonal cost %d: FindPath = %v, %d with %d diagonals; want cost %d",
+				tt.diagonalCost, path, cost, diagonals, tt.cost)
+		}
+	}
+}
+
+func TestEnterAndExitCosts(t *testing.T) {
+	mud := Node{1, 0}
+	tests := []struct {
+		name        string
+		setup       func(g *Grid)
+		into, outOf Cost
+	}{
+		{"plain", func(g *Grid) {}, 1, 1},
+		{"enter cost", func(g *Grid) { g.Weights[mud] = 5 }, 5, 1},
+		{"exit cost", func(g *Grid) { g.ExitCosts[mud] = 5 }, 1, 6},
+		{"both", func(g *Grid) {
+			g.Weights[mud] = 5
+			g.ExitCosts[mud] = 5
+		}, 5, 6},
+	}
+	for _, tt := range tests {
+		g := NewGrid(3, 1)
+		tt.setup(g)
+		if _, cost := FindPath(g, Node{0, 0}, mud); cost != tt.into {
+			t.Errorf("%s: into the mud costs %d, want %d", tt.name, cost, tt.into)
+		}
+		if _, cost := FindPath(g, mud, Node{2, 0}); cost != tt.outOf {
+			t.Errorf("%s: out of the mud costs %d, want %d", tt.name, cost, tt.outOf)
+		}
+	}
+}
+
+func TestWallProximityPenaltyCentersPath(t *testing.T) {
+	// a corridor three cells wide between walls along rows 0 and 4
+	g := NewGrid(12, 5)
+	for x := 0; x < g.Width; x++ {
+		g.Impassable[Node{x, 0}] = true
+		g.Impassable[Node{x, 4}] = true
+	}
+	start, goal := Node{0, 1}, Node{11, 1}
+	if _, cost := FindPath(g, start, goal); cost != 11 {
+		t.Fatalf("without a penalty: cost = %d, want 11", cost)
+	}
+
+	// every cell beside the walls costs 5 more, so the path steps into the
+	// middle row and only comes back to reach the goal
+	g.WallProximityPenalty = 5
+	path, cost := FindPath(g, start, goal)
+	if cost != 11+5 {
+		t.Fatalf("with a penalty: FindPath = %v, %d; want cost 16", path, cost)
+	}
+	for _, n := range path[1 : len(path)-1] {
+		if n.Y != 2 {
+			t.Errorf("path %v leaves the middle of the corridor at %v", path, n)
+			break
+		}
+	}
+}
+
+func TestDangerDetour(t *testing.T) {
+	g := NewGrid(7, 5)
+	start, goal := Node{0, 2}, Node{6, 2}
+
+	// enemy influence across the middle of the straight line: going round
+	// it through row 0 or 4 is no longer
+	for y := 1; y <= 3; y++ {
+		g.Danger[Node{3, y}] = 10
+	}
+	path, cost := FindPath(g, start, goal)
+	if cost != 6 {
+		t.Fatalf("FindPath = %v, %d; want the detour at cost 6", path, cost)
+	}
+	for _, n := range path {
+		if g.Danger[n] != 0 {
+			t.Errorf("path %v crosses the danger at %v", path, n)
+		}
+	}
+
+	// once it spans the whole column there is no way round, only through
+	g.Precompute()
+	g.Danger[Node{3, 0}] = 10
+	g.Danger[Node{3, 4}] = 10
+	g.InvalidateNeighbors()
+	if path, cost := FindPath(g, start, goal); cost != 6+10 {
+		t.Errorf("FindPath = %v, %d; want cost 16", path, cost)
+	}
+}
+
+func TestDiagonalPolicies(t *testing.T) {
+	// an L-shaped wall round the corner (0,0); from (1,1) the diagonal to
+	// (0,0) squeezes between both arms, those to (2,0) and (0,2) pass the
+	// end of one arm and the one to (2,2) is clear
+	tests := []struct {
+		policy    DiagonalPolicy
+		diagonals []Node
+		squeeze   Cost // cost from (0,0) to (1,1), or -1 for no path
+		around    Cost // cost from (2,0) to (0,2)
+	}{
+		{DiagonalAlways, []Node{{0, 0}, {2, 0}, {0, 2}, {2, 2}}, 1, 2},
+		{DiagonalNoCornerCutting, []Node{{2, 0}, {0, 2}, {2, 2}}, -1, 2},
+		{DiagonalOnlyWhenBothOpen, []Node{{2, 2}}, -1, 3},
+	}
+	for _, tt := range tests {
+		g := NewGrid(3, 3)
+		g.Impassable[Node{1, 0}] = true
+		g.Impassable[Node{0, 1}] = true
+		g.DiagonalPolicy = tt.policy
+
+		diagonals := make(map[Node]bool)
+		for _, arc := range g.GetNeighbors(Node{1, 1}) {
+			if arc.To.X != 1 && arc.To.Y != 1 {
+				diagonals[arc.To] = true
+			}
+		}
+		want := make(map[Node]bool)
+		for _, n := range tt.diagonals {
+			want[n] = true
+		}
+		if !maps.Equal(diagonals, want) {
+			t.Errorf("%v: diagonal neighbors of (1,1) = %v, want %v", tt.policy, diagonals, tt.diagonals)
+		}
+
+		path, cost := FindPath(g, Node{0, 0}, Node{1, 1})
+		if tt.squeeze < 0 && path != nil || tt.squeeze >= 0 && cost != tt.squeeze {
+			t.Errorf("%v: FindPath((0,0), (1,1)) = %v, %d; want cost %d", tt.policy, path, cost, tt.squeeze)
+		}
+		if path, cost := FindPath(g, Node{2, 0}, Node{0, 2}); cost != tt.around {
+			t.Errorf("%v: FindPath((2,0), (0,2)) = %v, %d; want cost %d", tt.policy, path, cost, tt.around)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package golang_astar
+
+import "fmt"
+
+// ManhattanHeuristic estimates remaining cost on a 4-connected grid
+func ManhattanHeuristic(a, b Node) Cost {
+	return Cost(a.ManhattanDistance(b))
+}
+
+// OctileHeuristic estimates remaining cost on an 8-connected grid where
+// orthogonal and diagonal steps cost orthoCost and diagCost
+func OctileHeuristic(a, b Node, orthoCost, diagCost Cost) Cost {
+	dx := a.X - b.X
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := a.Y - b.Y
+	if dy < 0 {
+		dy = -dy
+	}
+	lo, hi := dx, dy
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return diagCost*Cost(lo) + orthoCost*Cost(hi-lo)
+}
+
+// OctileHeuristic estimates remaining cost using the grid's step costs
+func (g *Grid) OctileHeuristic(a, b Node) Cost {
+	ortho, diag := g.stepCosts()
+	return OctileHeuristic(a, b, ortho, diag)
+}
+
+// defaultHeuristic returns Heuristic, scaled by MinEdgeCost when graph is a
+// Grid that sets it and measured the short way round when it wraps
+func defaultHeuristic(graph Graph) func(a, b Node) Cost {
+	g, ok := graph.(*Grid)
+	if !ok {
+		return Heuristic
+	}
+	h := Heuristic
+	if g.MinEdgeCost > 1 {
+		h = func(a, b Node) Cost {
+			return mulCost(Heuristic(a, b), g.MinEdgeCost)
+		}
+	}
+	return g.wrapHeuristic(h)
+}
+
+// HeuristicType names one of the built-in heuristics for FindPathH
+type HeuristicType int
+
+const (
+	// Chebyshev counts steps when diagonal moves cost the same as
+	// orthogonal ones; it is what FindPath uses
+	Chebyshev HeuristicType = iota
+	// Manhattan counts orthogonal steps, for 4-connected grids
+	Manhattan
+	// Euclidean is the straight-line distance in orthogonal step costs
+	Euclidean
+	// Octile uses the grid's orthogonal and diagonal step costs
+	Octile
+	// Zero estimates nothing, turning the search into Dijkstra's algorithm
+	Zero
+)
+
+// String returns the name of the heuristic
+func (t HeuristicType) String() string {
+	switch t {
+	case Chebyshev:
+		return "Chebyshev"
+	case Manhattan:
+		return "Manhattan"
+	case Euclidean:
+		return "Euclidean"
+	case Octile:
+		return "Octile"
+	case Zero:
+		return "Zero"
+	}
+	return fmt.Sprintf("HeuristicType(%d)", int(t))
+}
+
+// forGrid returns the heuristic function t names on grid, or nil for Zero
+// and unknown types
+func (t HeuristicType) forGrid(grid *Grid) func(a, b Node) Cost {
+	switch t {
+	case Chebyshev:
+		return defaultHeuristic(grid)
+	case Manhattan:
+		return grid.wrapHeuristic(ManhattanHeuristic)
+	case Euclidean:
+		return grid.wrapHeuristic(grid.euclidean)
+	case Octile:
+		return grid.wrapHeuristic(grid.OctileHeuristic)
+	}
+	return nil
+}
+
+// FindPathH finds the shortest path between start and goal using the
+// built-in heuristic h. Unknown types search without a heuristic, like
+// Zero; use FindPathWithHeuristic for a custom one.
+func FindPathH(grid *Grid, start, goal Node, h HeuristicType) ([]Node, Cost) {
+	return FindPathWithHeuristic(grid, start, goal, h.forGrid(grid))
+}
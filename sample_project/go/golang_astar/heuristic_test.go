@@ -0,0 +1,21 @@
+package golang_astar
+
+import "testing"
+
+func TestOctileHeuristicExpandsFewerNodes(t *testing.T) {
+	g := NewGrid(40, 40)
+	g.OrthogonalCost, g.DiagonalCost = 10, 14
+	start, goal := Node{0, 0}, Node{39, 17}
+
+	var octile, chebyshev SearchStats
+	_, octileCost, _ := search(g, start, goal, searchOptions{h: g.OctileHeuristic, stats: &octile})
+	_, chebyshevCost, _ := search(g, start, goal, searchOptions{h: Heuristic, stats: &chebyshev})
+
+	if want := Cost(17*14 + 22*10); octileCost != want || chebyshevCost != want {
+		t.Fatalf("costs = %d (octile), %d (Chebyshev); want %d", octileCost, chebyshevCost, want)
+	}
+	if octile.NodesExpanded >= chebyshev.NodesExpanded {
+		t.Errorf("octile expanded %d nodes, Chebyshev %d; want fewer",
+			octile.NodesExpanded, chebyshev.NodesExpanded)
+	}
+}
@@ -0,0 +1,67 @@
+package golang_astar
+
+import "fmt"
+
+// Hex represents a cell of a hexagonal grid in axial coordinates
+type Hex struct {
+	Q, R int
+}
+
+// String provides a string representation of Hex
+func (h Hex) String() string {
+	return fmt.Sprintf("(%d,%d)", h.Q, h.R)
+}
+
+// hexDirections are the axial offsets of the six adjacent hexes
+var hexDirections = [6]Hex{{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1}}
+
+// HexGrid represents a hexagonal search space with barriers. Valid cells
+// have 0 <= Q < Width and 0 <= R < Height, which lays out as a rhombus.
+type HexGrid struct {
+	Width    int
+	Height   int
+	Barriers map[Hex]bool
+}
+
+// NewHexGrid creates a new hex grid with the given dimensions
+func NewHexGrid(width, height int) *HexGrid {
+	return &HexGrid{
+		Width:    width,
+		Height:   height,
+		Barriers: make(map[Hex]bool),
+	}
+}
+
+// IsValidPosition checks if a position is within grid bounds
+func (g *HexGrid) IsValidPosition(h Hex) bool {
+	return h.Q >= 0 && h.Q < g.Width && h.R >= 0 && h.R < g.Height
+}
+
+// GetNeighbors returns valid neighboring hexes
+func (g *HexGrid) GetNeighbors(h Hex) []ArcG[Hex] {
+	neighbors := make([]ArcG[Hex], 0, 6)
+	for _, d := range hexDirections {
+		next := Hex{h.Q + d.Q, h.R + d.R}
+		if !g.IsValidPosition(next) {
+			continue
+		}
+
+		cost := Cost(1)
+		if g.Barriers[next] {
+			cost = 100
+		}
+		neighbors = append(neighbors, ArcG[Hex]{next, cost})
+	}
+	return neighbors
+}
+
+// HexDistance returns the number of steps between two hexes
+func HexDistance(a, b Hex) Cost {
+	dq, dr := a.Q-b.Q, a.R-b.R
+	return Cost((abs(dq) + abs(dr) + abs(dq+dr)) / 2)
+}
+
+// FindPathHex finds the shortest path between start and goal in a hex grid
+func FindPathHex(grid *HexGrid, start, goal Hex) ([]Hex, Cost) {
+	return FindPathG(start, goal, grid.GetNeighbors, HexDistance)
+}
@@ -0,0 +1,22 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathHexMatchesHexDistance(t *testing.T) {
+	g := NewHexGrid(8, 8)
+	start := Hex{3, 4}
+	for q := 0; q < g.Width; q++ {
+		for r := 0; r < g.Height; r++ {
+			goal := Hex{q, r}
+			path, cost := FindPathHex(g, start, goal)
+			if want := HexDistance(start, goal); cost != want || len(path) != int(want)+1 {
+				t.Fatalf("%v -> %v: FindPathHex = %v, %d; want %d steps", start, goal, path, cost, want)
+			}
+			for i := 1; i < len(path); i++ {
+				if HexDistance(path[i-1], path[i]) != 1 {
+					t.Fatalf("%v -> %v: hexes %v and %v are not adjacent", start, goal, path[i-1], path[i])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package golang_astar
+
+import (
+	"slices"
+	"sort"
+)
+
+// HPA is a grid abstracted for hierarchical pathfinding (HPA*). The grid is
+// cut into square clusters, and the cells where paths can cross from one
+// cluster to the next become the nodes of a much smaller abstract graph,
+// joined by the cost of the cheapest path between them inside a cluster.
+// Build one with BuildHPA and build it again after the grid changes.
+type HPA struct {
+	grid        *Grid
+	clusterSize int
+	edges       map[Node][]Arc
+	byCluster   map[Node][]Node // abstract nodes in each cluster
+}
+
+// hpaRunSplit is the length from which a run of border crossings gets a
+// transition at each end instead of a single one in the middle
+const hpaRunSplit = 6
+
+// BuildHPA cuts grid into clusters of clusterSize by clusterSize cells and
+// precomputes the abstract graph over them. A clusterSize below 1 is
+// treated as 1.
+func BuildHPA(grid *Grid, clusterSize int) *HPA {
+	h := &HPA{
+		grid:        grid,
+		clusterSize: max(clusterSize, 1),
+		edges:       make(map[Node][]Arc),
+		byCluster:   make(map[Node][]Node),
+	}
+
+	// Every move between adjacent cells of different clusters is a
+	// crossing. Cells that cross straight over a border are grouped by the
+	// pair of clusters they join; cells that can only cross diagonally are
+	// kept apart, since their way into the other cluster may not connect
+	// to their neighbors'.
+	type pair struct{ from, to Node }
+	straight := make(map[pair][]Node)
+	diagonal := make(map[pair][]Node)
+	for x := 0; x < grid.Width; x++ {
+		for y := 0; y < grid.Height; y++ {
+			n := Node{x, y}
+			if grid.Impassable[n] {
+				continue
+			}
+			crosses := make(map[Node]bool) // cluster -> crosses straight
+			for _, arc := range grid.Neighbors(n) {
+				if c := h.cluster(arc.To); isAdjacent(n, arc.To) && c != h.cluster(n) {
+					crosses[c] = crosses[c] || n.X == arc.To.X || n.Y == arc.To.Y
+				}
+			}
+			for c, isStraight := range crosses {
+				p := pair{h.cluster(n), c}
+				if isStraight {
+					straight[p] = append(straight[p], n)
+				} else {
+					diagonal[p] = append(diagonal[p], n)
+				}
+			}
+		}
+	}
+
+	// Each unbroken run of straight crossings along a border gets one or two
+	// transitions, and each diagonal crossing its own. A transition keeps
+	// all of its moves into the other cluster.
+	abstract := make(map[Node]bool)
+	addTransition := func(n Node, to Node) {
+		for _, arc := range grid.Neighbors(n) {
+			if isAdjacent(n, arc.To) && h.cluster(arc.To) == to {
+				h.edges[n] = append(h.edges[n], arc)
+				abstract[n], abstract[arc.To] = true, true
+			}
+		}
+	}
+	pairs := make([]pair, 0, len(straight)+len(diagonal))
+	for p := range straight {
+		pairs = append(pairs, p)
+	}
+	for p := range diagonal {
+		if straight[p] == nil {
+			pairs = append(pairs, p)
+		}
+	}
+	// a fixed order keeps the abstract graph, and so the paths, the same
+	// from one build to the next
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].from != pairs[j].from {
+			return pairs[i].from.less(pairs[j].from)
+		}
+		return pairs[i].to.less(pairs[j].to)
+	})
+	for _, p := range pairs {
+		cells := straight[p]
+		for start := 0; start < len(cells); {
+			end := start + 1
+			for end < len(cells) && cells[end].ManhattanDistance(cells[end-1]) == 1 {
+				end++
+			}
+			if end-start < hpaRunSplit {
+				addTransition(cells[(start+end-1)/2], p.to)
+			} else {
+				addTransition(cells[start], p.to)
+				addTransition(cells[end-1], p.to)
+			}
+			start = end
+		}
+		for _, n := range diagonal[p] {
+			addTransition(n, p.to)
+		}
+	}
+
+	for n := range abstract {
+		c := h.cluster(n)
+		h.byCluster[c] = append(h.byCluster[c], n)
+	}
+	for c, nodes := range h.byCluster {
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].less(nodes[j]) })
+		for _, n := range nodes {
+			costs := h.costsFrom(n, c)
+			for _, m := range nodes {
+				if cost, ok := costs[m]; ok && m != n {
+					h.edges[n] = append(h.edges[n], Arc{m, cost})
+				}
+			}
+		}
+	}
+	return h
+}
+
+// cluster returns the coordinates of the cluster holding n
+func (h *HPA) cluster(n Node) Node {
+	return Node{n.X / h.clusterSize, n.Y / h.clusterSize}
+}
+
+// within keeps only the arcs that stay inside cluster c
+func (h *HPA) within(c Node, arcs []Arc) []Arc {
+	kept := make([]Arc, 0, len(arcs))
+	for _, arc := range arcs {
+		if h.grid.IsValidPosition(arc.To) && h.cluster(arc.To) == c {
+			kept = append(kept, arc)
+		}
+	}
+	return kept
+}
+
+// costsFrom returns the cost of reaching each cell of cluster c from n
+// without leaving the cluster
+func (h *HPA) costsFrom(n, c Node) map[Node]Cost {
+	var s Searcher
+	s.search(h.grid, n, n, searchOptions{
+		isGoal: never,
+		successors: func(current *searchNode) []Arc {
+			return h.within(c, h.grid.Neighbors(current.pos))
+		},
+	})
+	return s.settled()
+}
+
+// costsTo returns the cost of reaching n from each cell of cluster c
+// without leaving the cluster
+func (h *HPA) costsTo(n, c Node) map[Node]Cost {
+	var s Searcher
+	s.search(h.grid, n, n, searchOptions{
+		isGoal: never,
+		successors: func(current *searchNode) []Arc {
+			return h.within(c, h.grid.predecessors(current.pos))
+		},
+	})
+	return s.settled()
+}
+
+// hpaQuery is the abstract graph with a query's start and goal linked in
+type hpaQuery struct {
+	*HPA
+	extra map[Node][]Arc
+}
+
+// Neighbors returns the abstract arcs out of n
+func (q *hpaQuery) Neighbors(n Node) []Arc {
+	return slices.Concat(q.edges[n], q.extra[n])
+}
+
+// FindPath finds a path between start and goal by searching the abstract
+// graph and then refining each of its hops into cells. The path is close
+// to the shortest but not always the shortest, since paths may only cross
+// between clusters at the chosen transitions; portals leading out of a
+// cluster are not used.
+func (h *HPA) FindPath(start, goal Node) ([]Node, Cost) {
+	grid := h.grid
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+	if start == goal {
+		return []Node{start}, 0
+	}
+
+	// Link start and goal to the transitions of their clusters, and to each
+	// other when they share one. Moves from start straight into another
+	// cluster are linked too, as start need not be a transition itself.
+	q := &hpaQuery{HPA: h, extra: make(map[Node][]Arc)}
+	sc, gc := h.cluster(start), h.cluster(goal)
+	link := func(from, c Node) {
+		costs := h.costsFrom(from, c)
+		for _, n := range h.byCluster[c] {
+			if cost, ok := costs[n]; ok && n != from {
+				q.extra[from] = append(q.extra[from], Arc{n, cost})
+			}
+		}
+		if cost, ok := costs[goal]; ok && c == gc {
+			q.extra[from] = append(q.extra[from], Arc{goal, cost})
+		}
+	}
+	link(start, sc)
+	for _, arc := range grid.Neighbors(start) {
+		if c := h.cluster(arc.To); isAdjacent(start, arc.To) && c != sc {
+			q.extra[start] = append(q.extra[start], arc)
+			link(arc.To, c)
+		}
+	}
+	toGoal := h.costsTo(goal, gc)
+	for _, n := range h.byCluster[gc] {
+		if cost, ok := toGoal[n]; ok {
+			q.extra[n] = append(q.extra[n], Arc{goal, cost})
+		}
+	}
+
+	hops, _, _ := search(q, start, goal, searchOptions{h: defaultHeuristic(grid)})
+	if hops == nil {
+		return nil, 0
+	}
+
+	// Hops inside a cluster are searched again within it; hops between
+	// clusters are single moves
+	path := []Node{start}
+	var total Cost
+	for i := 1; i < len(hops); i++ {
+		a, b := hops[i-1], hops[i]
+		if c := h.cluster(a); c == h.cluster(b) {
+			leg, cost, _ := search(grid, a, b, searchOptions{
+				h: defaultHeuristic(grid),
+				successors: func(current *searchNode) []Arc {
+					return h.within(c, grid.Neighbors(current.pos))
+				},
+			})
+			path = append(path, leg[1:]...)
+			total = addCost(total, cost)
+		} else {
+			cost, _ := grid.arcCost(a, b)
+			path = append(path, b)
+			total = addCost(total, cost)
+		}
+	}
+	return path, total
+}
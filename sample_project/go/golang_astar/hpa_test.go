@@ -0,0 +1,84 @@
+package golang_astar
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHPAFindPathNearOptimal(t *testing.T) {
+	r := rand.New(rand.NewSource(77))
+	for i := 0; i < 60; i++ {
+		var g *Grid
+		if i%2 == 0 {
+			g = NewRandomGrid(40, 40, 0.25, r.Int63())
+		} else {
+			g = NewMazeGrid(41, 41, r.Int63())
+		}
+		g.Impassable, g.Barriers = g.Barriers, make(map[Node]bool)
+		h := BuildHPA(g, 8)
+		start := Node{r.Intn(g.Width), r.Intn(g.Height)}
+		goal := Node{r.Intn(g.Width), r.Intn(g.Height)}
+
+		want, wantCost := FindPath(g, start, goal)
+		path, cost := h.FindPath(start, goal)
+		if (path == nil) != (want == nil) {
+			t.Fatalf("grid %d, %v -> %v: HPA found %v, FindPath %v", i, start, goal, path, want)
+		}
+		if path == nil {
+			continue
+		}
+		// checkPath runs ValidatePath and checks the ends and the cost
+		if err := g.checkPath(path, start, goal, cost); err != nil {
+			t.Fatalf("grid %d: path %v: %v", i, path, err)
+		}
+		// crossing only at transitions costs a little; allow half again
+		if 2*cost > 3*wantCost {
+			t.Errorf("grid %d, %v -> %v: HPA cost %d, more than 1.5 times the shortest %d",
+				i, start, goal, cost, wantCost)
+		}
+	}
+}
+
+func TestHPAUnreachable(t *testing.T) {
+	g := NewGrid(24, 24)
+	for y := 0; y < g.Height; y++ {
+		g.Impassable[Node{12, y}] = true
+	}
+	h := BuildHPA(g, 8)
+	if path, cost := h.FindPath(Node{2, 2}, Node{20, 20}); path != nil {
+		t.Errorf("FindPath across a solid wall = %v, %d; want nil", path, cost)
+	}
+	if path, _ := h.FindPath(Node{2, 2}, Node{24, 0}); path != nil {
+		t.Errorf("FindPath to an off-grid goal = %v; want nil", path)
+	}
+}
+
+func TestHPASameCluster(t *testing.T) {
+	g := NewGrid(16, 16)
+	h := BuildHPA(g, 8)
+
+	// straight across the cluster
+	start, goal := Node{1, 1}, Node{6, 4}
+	if path, cost := h.FindPath(start, goal); cost != 5 || g.checkPath(path, start, goal, cost) != nil {
+		t.Errorf("FindPath = %v, %d; want a valid path at cost 5", path, cost)
+	}
+	if path, cost := h.FindPath(start, start); cost != 0 || len(path) != 1 {
+		t.Errorf("FindPath to start = %v, %d; want just start", path, cost)
+	}
+
+	// a wall splitting the cluster leaves a way round through the one below
+	for y := 0; y < 8; y++ {
+		g.Impassable[Node{4, y}] = true
+	}
+	h = BuildHPA(g, 8)
+	path, cost := h.FindPath(start, goal)
+	if path == nil {
+		t.Fatal("FindPath found no way round the wall")
+	}
+	if err := g.checkPath(path, start, goal, cost); err != nil {
+		t.Errorf("path %v: %v", path, err)
+	}
+	if _, want := FindPath(g, start, goal); 2*cost > 3*want {
+		t.Errorf("FindPath cost = %d, more than 1.5 times the shortest %d", cost, want)
+	}
+}
@@ -0,0 +1,75 @@
+package golang_astar
+
+// idaSearch holds the state of one iterative-deepening search
+type idaSearch struct {
+	grid   *Grid
+	goal   Node
+	h      func(a, b Node) Cost
+	path   []Node
+	onPath map[Node]bool
+}
+
+// probe extends the path depth-first while f stays within bound. It
+// reports whether the goal was reached and otherwise the smallest f that
+// exceeded bound, which becomes the next bound.
+func (s *idaSearch) probe(g, bound Cost) (Cost, bool) {
+	n := s.path[len(s.path)-1]
+	f := addCost(g, s.h(n, s.goal))
+	if f > bound {
+		return f, false
+	}
+	if n == s.goal {
+		return g, true
+	}
+
+	next := MaxCost
+	for _, arc := range s.grid.Neighbors(n) {
+		if s.onPath[arc.To] {
+			continue
+		}
+		s.path = append(s.path, arc.To)
+		s.onPath[arc.To] = true
+		t, found := s.probe(addCost(g, arc.Cost), bound)
+		if found {
+			return t, true
+		}
+		s.path = s.path[:len(s.path)-1]
+		delete(s.onPath, arc.To)
+		next = min(next, t)
+	}
+	return next, false
+}
+
+// FindPathIDA finds the shortest path between start and goal with
+// iterative-deepening A*: repeated depth-first searches that each give up
+// on any branch whose f exceeds a bound, raising the bound to the smallest
+// f that went over it until the goal is reached. It keeps nothing but the
+// current path in memory, so it fits where FindPath's open and closed sets
+// would not, but it pays with time: every iteration expands the cells of
+// the previous one again, and with many distinct step costs there can be
+// many iterations. An unreachable goal is only detected once every
+// loop-free path from start has been tried, which on an open grid is
+// effectively never.
+func FindPathIDA(grid *Grid, start, goal Node) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+	s := &idaSearch{
+		grid:   grid,
+		goal:   goal,
+		h:      defaultHeuristic(grid),
+		path:   []Node{start},
+		onPath: map[Node]bool{start: true},
+	}
+	bound := s.h(start, goal)
+	for {
+		t, found := s.probe(0, bound)
+		if found {
+			return s.path, t
+		}
+		if t == MaxCost {
+			return nil, 0
+		}
+		bound = t
+	}
+}
@@ -0,0 +1,34 @@
+package golang_astar
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFindPathIDAMatchesFindPath(t *testing.T) {
+	// IDA* tries every loop-free path before giving up on a goal, so keep
+	// the grids small and the barriers soft enough to cross
+	r := rand.New(rand.NewSource(73))
+	for i := 0; i < 100; i++ {
+		g := NewRandomGrid(2+r.Intn(4), 2+r.Intn(4), 0.3, r.Int63())
+		if r.Intn(2) == 0 {
+			g.Connectivity = 4
+		}
+		start := Node{r.Intn(g.Width), r.Intn(g.Height)}
+		goal := Node{r.Intn(g.Width), r.Intn(g.Height)}
+
+		_, want := FindPath(g, start, goal)
+		path, cost := FindPathIDA(g, start, goal)
+		if cost != want {
+			t.Fatalf("grid %d, %v -> %v: got %v at %d, want cost %d", i, start, goal, path, cost, want)
+		}
+		if walked, err := g.PathCost(path); err != nil || walked != cost || path[0] != start || path[len(path)-1] != goal {
+			t.Fatalf("grid %d: path %v walks for %d (%v), reported %d", i, path, walked, err, cost)
+		}
+	}
+
+	g := NewGrid(30, 30)
+	if path, cost := FindPathIDA(g, Node{0, 0}, Node{29, 17}); cost != 29 {
+		t.Errorf("open grid: FindPathIDA = %v, %d; want cost 29", path, cost)
+	}
+}
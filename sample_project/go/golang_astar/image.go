@@ -0,0 +1,23 @@
+package golang_astar
+
+import (
+	"image"
+	"image/color"
+)
+
+// NewGridFromImage creates a grid the size of img, with a barrier wherever
+// a pixel's gray level is below threshold. The top-left pixel of the image
+// bounds becomes Node{0, 0}.
+func NewGridFromImage(img image.Image, threshold uint8) *Grid {
+	b := img.Bounds()
+	grid := NewGrid(b.Dx(), b.Dy())
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			gray := color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray)
+			if gray.Y < threshold {
+				grid.Barriers[Node{x, y}] = true
+			}
+		}
+	}
+	return grid
+}
@@ -0,0 +1,29 @@
+package golang_astar
+
+import (
+	"image"
+	"image/color"
+	"maps"
+	"testing"
+)
+
+func TestNewGridFromImage(t *testing.T) {
+	// bounds that do not start at the origin still map to Node{0, 0}
+	img := image.NewGray(image.Rect(10, 20, 14, 23))
+	for x := 10; x < 14; x++ {
+		for y := 20; y < 23; y++ {
+			img.SetGray(x, y, color.Gray{255})
+		}
+	}
+	img.SetGray(10, 20, color.Gray{0})
+	img.SetGray(12, 21, color.Gray{99})
+	img.SetGray(13, 22, color.Gray{100})
+
+	g := NewGridFromImage(img, 100)
+	if g.Width != 4 || g.Height != 3 {
+		t.Fatalf("size = %dx%d, want 4x3", g.Width, g.Height)
+	}
+	if want := map[Node]bool{{0, 0}: true, {2, 1}: true}; !maps.Equal(g.Barriers, want) {
+		t.Errorf("barriers = %v, want %v", g.Barriers, want)
+	}
+}
@@ -0,0 +1,46 @@
+package golang_astar
+
+import "math/rand"
+
+// FindPathJittered finds a good but not necessarily shortest path between
+// start and goal by adding a random extra cost between 0 and jitter to each
+// move, so agents heading for the same goal with different seeds spread
+// out over slightly different routes. The same seed always gives the same
+// path. Since moves only get dearer the distance heuristic stays
+// admissible, and the path costs at most jitter per move of the shortest
+// path more than it. The cost returned is the path's real cost, without
+// the jitter.
+func FindPathJittered(grid *Grid, start, goal Node, jitter Cost, seed int64) ([]Node, Cost) {
+	if jitter <= 0 {
+		return FindPath(grid, start, goal)
+	}
+	if outOfBounds(grid, start, goal) {
+		return nil, 0
+	}
+
+	// each move keeps its extra cost for the whole search
+	r := rand.New(rand.NewSource(seed))
+	extra := make(map[[2]Node]Cost)
+	path, _, _ := search(grid, start, goal, searchOptions{
+		h: defaultHeuristic(grid),
+		successors: func(current *searchNode) []Arc {
+			arcs := grid.Neighbors(current.pos)
+			jittered := make([]Arc, len(arcs))
+			for i, arc := range arcs {
+				move := [2]Node{current.pos, arc.To}
+				e, ok := extra[move]
+				if !ok {
+					e = Cost(r.Int63n(int64(jitter) + 1))
+					extra[move] = e
+				}
+				jittered[i] = Arc{arc.To, addCost(arc.Cost, e)}
+			}
+			return jittered
+		},
+	})
+	if path == nil {
+		return nil, 0
+	}
+	cost, _ := grid.PathCost(path)
+	return path, cost
+}
@@ -0,0 +1,236 @@
+package golang_astar
+
+// jumpSearch holds the state of one jump point search
+type jumpSearch struct {
+	grid *Grid
+	goal Node
+}
+
+// walkable reports whether n is on the grid and not a wall
+func (j *jumpSearch) walkable(n Node) bool {
+	return !j.grid.blocked(n)
+}
+
+// jump moves from n in direction (dx, dy) until it finds a jump point,
+// reporting false if it runs into a wall first
+func (j *jumpSearch) jump(n Node, dx, dy int) (Node, bool) {
+	for {
+		if !j.walkable(n) {
+			return Node{}, false
+		}
+		if n == j.goal {
+			return n, true
+		}
+		x, y := n.X, n.Y
+		switch {
+		case dx != 0 && dy != 0:
+			// a diagonal stops wherever a straight jump would find something
+			if _, ok := j.jump(Node{x + dx, y}, dx, 0); ok {
+				return n, true
+			}
+			if _, ok := j.jump(Node{x, y + dy}, 0, dy); ok {
+				return n, true
+			}
+		case dx != 0:
+			if j.walkable(Node{x, y - 1}) && !j.walkable(Node{x - dx, y - 1}) ||
+				j.walkable(Node{x, y + 1}) && !j.walkable(Node{x - dx, y + 1}) {
+				return n, true
+			}
+		default:
+			if j.walkable(Node{x - 1, y}) && !j.walkable(Node{x - 1, y - dy}) ||
+				j.walkable(Node{x + 1, y}) && !j.walkable(Node{x + 1, y - dy}) {
+				return n, true
+			}
+		}
+		// never squeeze past a wall, even diagonally
+		if !j.walkable(Node{x + dx, y}) || !j.walkable(Node{x, y + dy}) {
+			return Node{}, false
+		}
+		n = Node{x + dx, y + dy}
+	}
+}
+
+// directions returns the pruned set of directions worth jumping in from
+// current, based on the direction it was reached from
+func (j *jumpSearch) directions(current *searchNode) []Node {
+	x, y := current.pos.X, current.pos.Y
+	open := func(dx, dy int) bool { return j.walkable(Node{x + dx, y + dy}) }
+
+	if current.parent == nil {
+		dirs := make([]Node, 0, 8)
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				if dx == 0 && dy == 0 || !open(dx, dy) {
+					continue
+				}
+				if dx != 0 && dy != 0 && (!open(dx, 0) || !open(0, dy)) {
+					continue
+				}
+				dirs = append(dirs, Node{dx, dy})
+			}
+		}
+		return dirs
+	}
+
+	dx, dy := sign(x-current.parent.pos.X), sign(y-current.parent.pos.Y)
+	dirs := make([]Node, 0, 5)
+	switch {
+	case dx != 0 && dy != 0:
+		if open(0, dy) {
+			dirs = append(dirs, Node{0, dy})
+		}
+		if open(dx, 0) {
+			dirs = append(dirs, Node{dx, 0})
+		}
+		if open(0, dy) && open(dx, 0) {
+			dirs = append(dirs, Node{dx, dy})
+		}
+	case dx != 0:
+		if open(dx, 0) {
+			dirs = append(dirs, Node{dx, 0})
+			if open(0, 1) {
+				dirs = append(dirs, Node{dx, 1})
+			}
+			if open(0, -1) {
+				dirs = append(dirs, Node{dx, -1})
+			}
+		}
+		if open(0, 1) {
+			dirs = append(dirs, Node{0, 1})
+		}
+		if open(0, -1) {
+			dirs = append(dirs, Node{0, -1})
+		}
+	default:
+		if open(0, dy) {
+			dirs = append(dirs, Node{0, dy})
+			if open(1, 0) {
+				dirs = append(dirs, Node{1, dy})
+			}
+			if open(-1, 0) {
+				dirs = append(dirs, Node{-1, dy})
+			}
+		}
+		if open(1, 0) {
+			dirs = append(dirs, Node{1, 0})
+		}
+		if open(-1, 0) {
+			dirs = append(dirs, Node{-1, 0})
+		}
+	}
+	return dirs
+}
+
+// successors returns arcs from current to each jump point it can reach
+func (j *jumpSearch) successors(current *searchNode) []Arc {
+	ortho, diag := j.grid.stepCosts()
+	var arcs []Arc
+	for _, d := range j.directions(current) {
+		next := current.pos.Add(d.X, d.Y)
+		jp, ok := j.jump(next, d.X, d.Y)
+		if !ok {
+			continue
+		}
+		steps := abs(jp.X - current.pos.X)
+		if dy := abs(jp.Y - current.pos.Y); dy > steps {
+			steps = dy
+		}
+		cost := ortho
+		if d.X != 0 && d.Y != 0 {
+			cost = diag
+		}
+		arcs = append(arcs, Arc{jp, mulCost(cost, Cost(steps))})
+	}
+	return arcs
+}
+
+// FindPathJPS finds the shortest path between start and goal using Jump
+// Point Search, which skips over the symmetric stretches of open grid that
+// FindPath expands one cell at a time.
+//
+// Barriers are treated as walls just like Impassable cells, and a diagonal
+// move needs both cells it passes to be free, whatever AllowCornerCutting
+// and DiagonalPolicy say. JPS itself only works on 8-connected grids with
+// uniform step costs, so on a grid with Connectivity 4, Weights, ExitCosts,
+// Danger, a WallProximityPenalty, Portals, NeighborFunc, AllowedDirections
+// or wrapping, FindPathJPS falls back to an ordinary A* search under the
+// same rules for walls and diagonals. The returned path still lists every
+// cell along the way.
+func FindPathJPS(grid *Grid, start, goal Node) ([]Node, Cost) {
+	j := &jumpSearch{grid: grid, goal: goal}
+	if !j.walkable(start) || !j.walkable(goal) {
+		return nil, 0
+	}
+	if !jumpable(grid) {
+		path, cost, _ := search(grid, start, goal, searchOptions{
+			h:          defaultHeuristic(grid),
+			successors: j.neighbors,
+		})
+		return path, cost
+	}
+	jumpPoints, cost, _ := search(grid, start, goal, searchOptions{
+		h:          grid.OctileHeuristic,
+		successors: j.successors,
+	})
+	if jumpPoints == nil {
+		return nil, 0
+	}
+
+	// Fill in the straight runs between consecutive jump points
+	path := []Node{start}
+	for _, jp := range jumpPoints[1:] {
+		last := path[len(path)-1]
+		dx, dy := sign(jp.X-last.X), sign(jp.Y-last.Y)
+		for n := last; n != jp; {
+			n = n.Add(dx, dy)
+			path = append(path, n)
+		}
+	}
+	return path, cost
+}
+
+// jumpable reports whether every move on grid follows the uniform-cost,
+// 8-connected rules jump point search relies on
+func jumpable(grid *Grid) bool {
+	return grid.Connectivity != 4 &&
+		len(grid.Weights) == 0 && len(grid.ExitCosts) == 0 && len(grid.Danger) == 0 &&
+		grid.WallProximityPenalty == 0 && len(grid.Portals) == 0 &&
+		grid.NeighborFunc == nil && grid.AllowedDirections == nil &&
+		!grid.wrapsX() && !grid.wrapsY()
+}
+
+// neighbors returns the grid's moves out of current that keep to jump point
+// search's rules: never onto a wall, and never diagonally past one
+func (j *jumpSearch) neighbors(current *searchNode) []Arc {
+	n := current.pos
+	var arcs []Arc
+	for _, arc := range j.grid.Neighbors(n) {
+		if !j.walkable(arc.To) {
+			continue
+		}
+		d := j.grid.nearestCopy(n, arc.To).Sub(n)
+		if abs(d.X) == 1 && abs(d.Y) == 1 &&
+			(!j.walkable(j.grid.wrap(n.Add(d.X, 0))) || !j.walkable(j.grid.wrap(n.Add(0, d.Y)))) {
+			continue
+		}
+		arcs = append(arcs, arc)
+	}
+	return arcs
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
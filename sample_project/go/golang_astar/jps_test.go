@@ -0,0 +1,91 @@
+package golang_astar
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// wallGrid returns a copy of g's barriers and rules under which FindPath
+// moves as FindPathJPS does: barriers are walls and diagonals need both
+// cells they pass to be free
+func wallGrid(g *Grid) *Grid {
+	w := *g
+	w.Barriers = make(map[Node]bool)
+	w.Impassable = make(map[Node]bool)
+	for n := range g.Barriers {
+		w.Impassable[n] = true
+	}
+	for n := range g.Impassable {
+		w.Impassable[n] = true
+	}
+	w.DiagonalPolicy = DiagonalOnlyWhenBothOpen
+	return &w
+}
+
+func TestFindPathJPSMatchesFindPath(t *testing.T) {
+	r := rand.New(rand.NewSource(15))
+	for i := 0; i < 300; i++ {
+		g := NewRandomGrid(2+r.Intn(20), 2+r.Intn(20), 0.25, r.Int63())
+		switch r.Intn(5) {
+		case 0:
+			g.Connectivity = 4
+		case 1:
+			g.Weights[Node{r.Intn(g.Width), r.Intn(g.Height)}] = 5
+		case 2:
+			g.Wrap = true
+		case 3:
+			g.OrthogonalCost, g.DiagonalCost = 10, 14
+		}
+		start := Node{r.Intn(g.Width), r.Intn(g.Height)}
+		goal := Node{r.Intn(g.Width), r.Intn(g.Height)}
+
+		path, cost := FindPathJPS(g, start, goal)
+		if g.blocked(start) || g.blocked(goal) {
+			if path != nil {
+				t.Fatalf("grid %d: path %v starts or ends on a wall", i, path)
+			}
+			continue
+		}
+		_, want := FindPath(wallGrid(g), start, goal)
+		if cost != want {
+			t.Fatalf("grid %d, %v -> %v: cost %d, want %d", i, start, goal, cost, want)
+		}
+		if path == nil {
+			continue
+		}
+		if walked, err := wallGrid(g).PathCost(path); err != nil || walked != cost {
+			t.Fatalf("grid %d: path %v walks for %d (%v), reported %d", i, path, walked, err, cost)
+		}
+	}
+}
+
+func TestFindPathJPSConnectivity4(t *testing.T) {
+	g := NewGrid(6, 6)
+	g.Connectivity = 4
+	path, cost := FindPathJPS(g, Node{0, 0}, Node{5, 5})
+	if cost != 10 {
+		t.Fatalf("FindPathJPS = %v, %d; want cost 10", path, cost)
+	}
+	for i := 1; i < len(path); i++ {
+		if ManhattanHeuristic(path[i-1], path[i]) != 1 {
+			t.Fatalf("move %v -> %v is not orthogonal", path[i-1], path[i])
+		}
+	}
+}
+
+func BenchmarkFindPathJPS(b *testing.B) {
+	g := NewRandomGrid(256, 256, 0.05, 15)
+	start, goal := Node{0, 0}, Node{255, 255}
+	j := &jumpSearch{grid: g, goal: goal}
+
+	var jps, astar SearchStats
+	search(g, start, goal, searchOptions{h: g.OctileHeuristic, successors: j.successors, stats: &jps})
+	search(wallGrid(g), start, goal, searchOptions{h: g.OctileHeuristic, stats: &astar})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FindPathJPS(g, start, goal)
+	}
+	b.ReportMetric(float64(jps.NodesExpanded), "jps-expanded")
+	b.ReportMetric(float64(astar.NodesExpanded), "astar-expanded")
+}
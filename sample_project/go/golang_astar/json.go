@@ -0,0 +1,185 @@
+package golang_astar
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// gridJSON is the saved form of a Grid. Cells are [x, y] pairs, since a
+// map keyed by Node has no JSON representation.
+type gridJSON struct {
+	Width                int          `json:"width"`
+	Height               int          `json:"height"`
+	Barriers             [][2]int     `json:"barriers,omitempty"`
+	Impassable           [][2]int     `json:"impassable,omitempty"`
+	Weights              []cellCost   `json:"weights,omitempty"`
+	ExitCosts            []cellCost   `json:"exitCosts,omitempty"`
+	Danger               []cellCost   `json:"danger,omitempty"`
+	Portals              []portalJSON `json:"portals,omitempty"`
+	WallProximityPenalty Cost         `json:"wallProximityPenalty,omitempty"`
+	Wrap                 bool         `json:"wrap,omitempty"`
+	WrapX                bool         `json:"wrapX,omitempty"`
+	WrapY                bool         `json:"wrapY,omitempty"`
+	Connectivity         int          `json:"connectivity,omitempty"`
+	OrthogonalCost       Cost         `json:"orthogonalCost,omitempty"`
+	DiagonalCost         Cost         `json:"diagonalCost,omitempty"`
+	MinEdgeCost          Cost         `json:"minEdgeCost,omitempty"`
+	AllowCornerCutting   *bool        `json:"allowCornerCutting,omitempty"`
+	DiagonalPolicy       int          `json:"diagonalPolicy,omitempty"`
+}
+
+// cellCost is a weighted cell, saved as [x, y, weight]
+type cellCost [3]int
+
+type portalJSON struct {
+	From [2]int `json:"from"`
+	To   [2]int `json:"to"`
+	Cost Cost   `json:"cost"`
+}
+
+// MarshalJSON saves the grid's size, cells and movement rules. NeighborFunc
+// and AllowedDirections are functions and are not saved.
+func (g *Grid) MarshalJSON() ([]byte, error) {
+	v := gridJSON{
+		Width:                g.Width,
+		Height:               g.Height,
+		Impassable:           cellList(g.Impassable),
+		WallProximityPenalty: g.WallProximityPenalty,
+		Wrap:                 g.Wrap,
+		WrapX:                g.WrapX,
+		WrapY:                g.WrapY,
+		Connectivity:         g.Connectivity,
+		OrthogonalCost:       g.OrthogonalCost,
+		DiagonalCost:         g.DiagonalCost,
+		MinEdgeCost:          g.MinEdgeCost,
+		AllowCornerCutting:   &g.AllowCornerCutting,
+		DiagonalPolicy:       int(g.DiagonalPolicy),
+	}
+	for _, n := range g.barrierList() {
+		v.Barriers = append(v.Barriers, [2]int{n.X, n.Y})
+	}
+	for _, n := range sortedKeys(g.Weights) {
+		v.Weights = append(v.Weights, cellCost{n.X, n.Y, int(g.Weights[n])})
+	}
+	for _, n := range sortedKeys(g.ExitCosts) {
+		v.ExitCosts = append(v.ExitCosts, cellCost{n.X, n.Y, int(g.ExitCosts[n])})
+	}
+	for _, n := range sortedKeys(g.Danger) {
+		v.Danger = append(v.Danger, cellCost{n.X, n.Y, int(g.Danger[n])})
+	}
+	for _, from := range sortedKeys(g.Portals) {
+		for _, portal := range g.Portals[from] {
+			v.Portals = append(v.Portals, portalJSON{
+				From: [2]int{from.X, from.Y},
+				To:   [2]int{portal.To.X, portal.To.Y},
+				Cost: portal.Cost,
+			})
+		}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON loads a grid saved by MarshalJSON, replacing everything but
+// NeighborFunc and AllowedDirections. Corner cutting defaults to allowed,
+// as in NewGrid, and cells outside the grid are rejected.
+func (g *Grid) UnmarshalJSON(data []byte) error {
+	var v gridJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	loaded := NewGrid(v.Width, v.Height)
+	loaded.NeighborFunc = g.NeighborFunc
+	loaded.AllowedDirections = g.AllowedDirections
+	loaded.WallProximityPenalty = v.WallProximityPenalty
+	loaded.Wrap, loaded.WrapX, loaded.WrapY = v.Wrap, v.WrapX, v.WrapY
+	loaded.Connectivity = v.Connectivity
+	loaded.OrthogonalCost = v.OrthogonalCost
+	loaded.DiagonalCost = v.DiagonalCost
+	loaded.MinEdgeCost = v.MinEdgeCost
+	if v.AllowCornerCutting != nil {
+		loaded.AllowCornerCutting = *v.AllowCornerCutting
+	}
+	loaded.DiagonalPolicy = DiagonalPolicy(v.DiagonalPolicy)
+
+	cell := func(kind string, c [2]int) (Node, error) {
+		n := Node{c[0], c[1]}
+		if !loaded.IsValidPosition(n) {
+			return n, fmt.Errorf("astar: %s %v is outside the grid", kind, n)
+		}
+		return n, nil
+	}
+	for _, c := range v.Barriers {
+		n, err := cell("barrier", c)
+		if err != nil {
+			return err
+		}
+		loaded.Barriers[n] = true
+	}
+	for _, c := range v.Impassable {
+		n, err := cell("impassable cell", c)
+		if err != nil {
+			return err
+		}
+		loaded.Impassable[n] = true
+	}
+	for _, w := range v.Weights {
+		n, err := cell("weighted cell", [2]int{w[0], w[1]})
+		if err != nil {
+			return err
+		}
+		loaded.Weights[n] = Cost(w[2])
+	}
+	for _, e := range v.ExitCosts {
+		n, err := cell("exit cost cell", [2]int{e[0], e[1]})
+		if err != nil {
+			return err
+		}
+		loaded.ExitCosts[n] = Cost(e[2])
+	}
+	for _, d := range v.Danger {
+		n, err := cell("danger cell", [2]int{d[0], d[1]})
+		if err != nil {
+			return err
+		}
+		loaded.Danger[n] = Cost(d[2])
+	}
+	for _, p := range v.Portals {
+		if err := loaded.AddPortal(Node{p.From[0], p.From[1]}, Node{p.To[0], p.To[1]}, p.Cost); err != nil {
+			return err
+		}
+	}
+
+	*g = *loaded
+	return nil
+}
+
+// cellList lists the cells set in m in a stable order
+func cellList(m map[Node]bool) [][2]int {
+	var cells [][2]int
+	for _, n := range sortedKeys(m) {
+		if m[n] {
+			cells = append(cells, [2]int{n.X, n.Y})
+		}
+	}
+	return cells
+}
+
+// sortedKeys returns the keys of m ordered by X and then by Y
+func sortedKeys[V any](m map[Node]V) []Node {
+	keys := make([]Node, 0, len(m))
+	for n := range m {
+		keys = append(keys, n)
+	}
+	slices.SortFunc(keys, func(a, b Node) int {
+		if a.less(b) {
+			return -1
+		}
+		if b.less(a) {
+			return 1
+		}
+		return 0
+	})
+	return keys
+}
@@ -0,0 +1,50 @@
+package golang_astar
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestGridJSONRoundTrip(t *testing.T) {
+	g := NewRandomGrid(12, 9, 0.2, 48)
+	g.Impassable[Node{5, 5}] = true
+	g.Weights[Node{2, 3}] = 4
+	g.ExitCosts[Node{3, 3}] = 2
+	g.Danger[Node{7, 1}] = 9
+	if err := g.AddPortal(Node{0, 8}, Node{11, 0}, 3); err != nil {
+		t.Fatal(err)
+	}
+	g.WallProximityPenalty = 1
+	g.WrapX = true
+	g.OrthogonalCost, g.DiagonalCost = 10, 14
+	g.MinEdgeCost = 10
+	g.AllowCornerCutting = false
+	g.DiagonalPolicy = DiagonalNoCornerCutting
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded Grid
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&loaded, g) {
+		t.Fatalf("loaded grid differs:\n got %+v\nwant %+v", &loaded, g)
+	}
+
+	start, goal := Node{0, 0}, Node{11, 8}
+	want, wantCost := FindPath(g, start, goal)
+	if path, cost := FindPath(&loaded, start, goal); cost != wantCost || !slices.Equal(path, want) {
+		t.Errorf("loaded grid: FindPath = %v, %d; want %v, %d", path, cost, want, wantCost)
+	}
+}
+
+func TestGridJSONRejectsOffGridCells(t *testing.T) {
+	var g Grid
+	if err := json.Unmarshal([]byte(`{"width":3,"height":3,"barriers":[[3,0]]}`), &g); err == nil {
+		t.Error("Unmarshal accepted a barrier outside the grid")
+	}
+}
@@ -0,0 +1,93 @@
+package golang_astar
+
+import (
+	"fmt"
+	"slices"
+)
+
+// prunedGraph hides some nodes and arcs of another graph
+type prunedGraph struct {
+	Graph
+	nodes map[Node]bool
+	arcs  map[[2]Node]bool
+}
+
+// Neighbors returns the arcs out of n that have not been pruned
+func (p *prunedGraph) Neighbors(n Node) []Arc {
+	if p.nodes[n] {
+		return nil
+	}
+	var arcs []Arc
+	for _, arc := range p.Graph.Neighbors(n) {
+		if !p.nodes[arc.To] && !p.arcs[[2]Node{n, arc.To}] {
+			arcs = append(arcs, arc)
+		}
+	}
+	return arcs
+}
+
+// FindKPaths finds up to k loopless paths between start and goal in order
+// of increasing cost, using Yen's algorithm: each path after the first
+// leaves an earlier one at some node and takes the shortest way to the goal
+// that no earlier path with the same beginning has taken. Fewer than k
+// paths are returned when no more exist.
+func FindKPaths(grid *Grid, start, goal Node, k int) ([][]Node, []Cost) {
+	if k <= 0 {
+		return nil, nil
+	}
+	first, cost := FindPath(grid, start, goal)
+	if first == nil {
+		return nil, nil
+	}
+	paths, costs := [][]Node{first}, []Cost{cost}
+	h := defaultHeuristic(grid)
+
+	type candidate struct {
+		path []Node
+		cost Cost
+	}
+	var candidates []candidate
+	seen := map[string]bool{fmt.Sprint(first): true}
+
+	for len(paths) < k {
+		prev := paths[len(paths)-1]
+		var rootCost Cost
+		for i := 0; i < len(prev)-1; i++ {
+			spur, root := prev[i], prev[:i+1]
+			pruned := &prunedGraph{Graph: grid, nodes: make(map[Node]bool), arcs: make(map[[2]Node]bool)}
+			for _, p := range paths {
+				if len(p) > i+1 && slices.Equal(p[:i+1], root) {
+					pruned.arcs[[2]Node{p[i], p[i+1]}] = true
+				}
+			}
+			for _, n := range root[:i] {
+				pruned.nodes[n] = true
+			}
+
+			if spurPath, spurCost := FindPathWithHeuristic(pruned, spur, goal, h); spurPath != nil {
+				path := append(slices.Clone(root), spurPath[1:]...)
+				if key := fmt.Sprint(path); !seen[key] {
+					seen[key] = true
+					candidates = append(candidates, candidate{path, addCost(rootCost, spurCost)})
+				}
+			}
+
+			step, _ := grid.arcCost(prev[i], prev[i+1])
+			rootCost = addCost(rootCost, step)
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		best := 0
+		for i, c := range candidates {
+			if c.cost < candidates[best].cost {
+				best = i
+			}
+		}
+		paths = append(paths, candidates[best].path)
+		costs = append(costs, candidates[best].cost)
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+	return paths, costs
+}
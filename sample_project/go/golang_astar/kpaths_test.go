@@ -0,0 +1,57 @@
+package golang_astar
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestFindKPaths(t *testing.T) {
+	g := NewRandomGrid(12, 12, 0.2, 60)
+	g.Impassable, g.Barriers = g.Barriers, make(map[Node]bool)
+	start, goal := Node{0, 0}, Node{11, 11}
+
+	paths, costs := FindKPaths(g, start, goal, 8)
+	if len(paths) != 8 || len(costs) != 8 {
+		t.Fatalf("FindKPaths returned %d paths and %d costs, want 8", len(paths), len(costs))
+	}
+	if want, _ := FindPath(g, start, goal); !slices.Equal(paths[0], want) {
+		t.Errorf("first path = %v, want FindPath's %v", paths[0], want)
+	}
+	seen := make(map[string]bool)
+	for i, path := range paths {
+		if err := g.checkPath(path, start, goal, costs[i]); err != nil {
+			t.Errorf("path %d %v: %v", i, path, err)
+		}
+		if i > 0 && costs[i] < costs[i-1] {
+			t.Errorf("path %d costs %d, less than the %d before it", i, costs[i], costs[i-1])
+		}
+		if key := fmt.Sprint(path); seen[key] {
+			t.Errorf("path %d %v is returned twice", i, path)
+		} else {
+			seen[key] = true
+		}
+		visited := make(map[Node]bool)
+		for _, n := range path {
+			if visited[n] {
+				t.Errorf("path %d %v visits %v twice", i, path, n)
+				break
+			}
+			visited[n] = true
+		}
+	}
+}
+
+func TestFindKPathsFewerThanK(t *testing.T) {
+	// round a 2x2 square there are just two ways from corner to corner
+	g := NewGrid(2, 2)
+	g.Connectivity = 4
+	paths, costs := FindKPaths(g, Node{0, 0}, Node{1, 1}, 5)
+	if len(paths) != 2 || !slices.Equal(costs, []Cost{2, 2}) {
+		t.Errorf("FindKPaths = %v, %v; want two paths at cost 2", paths, costs)
+	}
+
+	if paths, _ := FindKPaths(g, Node{0, 0}, Node{1, 1}, 0); paths != nil {
+		t.Errorf("FindKPaths with k = 0 = %v, want nil", paths)
+	}
+}
@@ -0,0 +1,30 @@
+package golang_astar
+
+// FindPathMaxCost finds the shortest path between start and goal if it
+// costs at most maxCost, reporting whether one was found. Nodes whose f
+// already exceeds maxCost are never added to the open set, so a goal that
+// is only reachable over budget is given up on early instead of searched
+// for.
+func FindPathMaxCost(grid *Grid, start, goal Node, maxCost Cost) ([]Node, Cost, bool) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0, false
+	}
+	h := defaultHeuristic(grid)
+	if h(start, goal) > maxCost {
+		return nil, 0, false
+	}
+	path, cost, _ := search(grid, start, goal, searchOptions{
+		h: h,
+		successors: func(current *searchNode) []Arc {
+			arcs := grid.Neighbors(current.pos)
+			within := make([]Arc, 0, len(arcs))
+			for _, arc := range arcs {
+				if addCost(addCost(current.g, arc.Cost), h(arc.To, goal)) <= maxCost {
+					within = append(within, arc)
+				}
+			}
+			return within
+		},
+	})
+	return path, cost, path != nil
+}
@@ -0,0 +1,29 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathMaxCost(t *testing.T) {
+	// the goal sits behind a wall with a single gap far down
+	g := NewGrid(10, 10)
+	for y := 0; y < 9; y++ {
+		g.Impassable[Node{5, y}] = true
+	}
+	start, goal := Node{0, 0}, Node{9, 0}
+	_, optimal := FindPath(g, start, goal)
+
+	tests := []struct {
+		maxCost Cost
+		found   bool
+	}{
+		{optimal, true},
+		{optimal + 5, true},
+		{optimal - 1, false}, // reachable, but only over budget
+		{5, false},           // below even the straight-line estimate
+	}
+	for _, tt := range tests {
+		path, cost, found := FindPathMaxCost(g, start, goal, tt.maxCost)
+		if found != tt.found || found && cost != optimal || !found && path != nil {
+			t.Errorf("max cost %d: got %v, %d, %t; want found %t", tt.maxCost, path, cost, found, tt.found)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package golang_astar
+
+// stepState is a position together with the number of moves taken to reach
+// it. done marks the single state every goal state leads to.
+type stepState struct {
+	pos   Node
+	steps int
+	done  bool
+}
+
+// FindPathMaxSteps finds the cheapest path between start and goal that
+// makes at most maxSteps moves, whatever it costs, reporting whether there
+// is one. It differs from FindPathMaxCost and FindPathBounded, which limit
+// cost and search effort: a cheap detour of many steps is rejected here in
+// favor of a dearer but shorter route. When the shortest path is short
+// enough it is returned as is; otherwise the search runs over (position,
+// steps taken) states with FindPathG.
+func FindPathMaxSteps(grid *Grid, start, goal Node, maxSteps int) ([]Node, Cost, bool) {
+	if maxSteps < 0 {
+		return nil, 0, false
+	}
+	path, cost := FindPath(grid, start, goal)
+	if path == nil {
+		return nil, 0, false
+	}
+	if PathSteps(path) <= maxSteps {
+		return path, cost, true
+	}
+
+	end := stepState{done: true}
+	h := defaultHeuristic(grid)
+	neighbors := func(s stepState) []ArcG[stepState] {
+		if s.pos == goal {
+			return []ArcG[stepState]{{end, 0}}
+		}
+		if s.steps == maxSteps {
+			return nil
+		}
+		arcs := grid.Neighbors(s.pos)
+		next := make([]ArcG[stepState], len(arcs))
+		for i, arc := range arcs {
+			next[i] = ArcG[stepState]{stepState{pos: arc.To, steps: s.steps + 1}, arc.Cost}
+		}
+		return next
+	}
+	estimate := func(s, _ stepState) Cost {
+		if s.done {
+			return 0
+		}
+		return h(s.pos, goal)
+	}
+
+	states, cost := FindPathG(stepState{pos: start}, end, neighbors, estimate)
+	if states == nil {
+		return nil, 0, false
+	}
+	path = make([]Node, len(states)-1)
+	for i, s := range states[:len(states)-1] {
+		path[i] = s.pos
+	}
+	return path, cost, true
+}
@@ -0,0 +1,43 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathMaxSteps(t *testing.T) {
+	g := NewGrid(5, 3)
+	g.Connectivity = 4
+	for x := 1; x < 4; x++ {
+		g.Weights[Node{x, 0}] = 5
+	}
+	start, goal := Node{0, 0}, Node{4, 0}
+
+	// the cheap way round the mud takes six moves, the way through it four
+	tests := []struct {
+		maxSteps int
+		cost     Cost
+		ok       bool
+	}{
+		{-1, 0, false},
+		{3, 0, false},
+		{4, 16, true},
+		{5, 16, true},
+		{6, 6, true},
+		{10, 6, true},
+	}
+	for _, tt := range tests {
+		path, cost, ok := FindPathMaxSteps(g, start, goal, tt.maxSteps)
+		if ok != tt.ok || cost != tt.cost {
+			t.Errorf("FindPathMaxSteps(%d) = %v, %d, %t; want cost %d, %t",
+				tt.maxSteps, path, cost, ok, tt.cost, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if steps := PathSteps(path); steps > tt.maxSteps {
+			t.Errorf("FindPathMaxSteps(%d) = %v, %d steps", tt.maxSteps, path, steps)
+		}
+		if err := g.checkPath(path, start, goal, cost); err != nil {
+			t.Errorf("FindPathMaxSteps(%d) = %v: %v", tt.maxSteps, path, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package golang_astar
+
+import "slices"
+
+// FindPathMulti finds the shortest path from start to whichever of goals is
+// cheapest to reach, returning the path, its cost and the goal reached. With
+// no goals it returns a nil path.
+func FindPathMulti(grid *Grid, start Node, goals []Node) ([]Node, Cost, Node) {
+	if len(goals) == 0 {
+		return nil, 0, Node{}
+	}
+
+	targets := make(map[Node]bool, len(goals))
+	for _, g := range goals {
+		targets[g] = true
+	}
+
+	// the nearest goal by estimate keeps the heuristic admissible
+	estimate := defaultHeuristic(grid)
+	h := func(n Node) Cost {
+		best := estimate(n, goals[0])
+		for _, g := range goals[1:] {
+			if h := estimate(n, g); h < best {
+				best = h
+			}
+		}
+		return best
+	}
+
+	path, cost := FindPathToPredicate(grid, start, func(n Node) bool { return targets[n] }, h)
+	if path == nil {
+		return nil, 0, Node{}
+	}
+	return path, cost, path[len(path)-1]
+}
+
+// FindPathsFrom finds the shortest path from start to each of goals with a
+// single Dijkstra search, which stops once every goal is settled, and reads
+// each path off the shared search tree. It is much cheaper than one
+// FindPath per goal when the goals are many. Goals that cannot be reached,
+// or lie outside the grid, are missing from the result.
+func FindPathsFrom(grid *Grid, start Node, goals []Node) map[Node][]Node {
+	paths := make(map[Node][]Node, len(goals))
+	if !grid.IsValidPosition(start) {
+		return paths
+	}
+	remaining := make(map[Node]bool, len(goals))
+	for _, g := range goals {
+		if grid.IsValidPosition(g) {
+			remaining[g] = true
+		}
+	}
+	if len(remaining) == 0 {
+		return paths
+	}
+
+	var s Searcher
+	settled := make([]Node, 0, len(remaining))
+	last, _, _ := s.search(grid, start, start, searchOptions{
+		isGoal: func(n Node) bool {
+			if remaining[n] {
+				delete(remaining, n)
+				settled = append(settled, n)
+			}
+			return len(remaining) == 0
+		},
+	})
+
+	parents := s.parents()
+	// the search returns on settling the last goal without closing it
+	if len(last) > 1 {
+		parents[last[len(last)-1]] = last[len(last)-2]
+	}
+	for _, g := range settled {
+		path := []Node{g}
+		for n := g; n != start; {
+			n = parents[n]
+			path = append(path, n)
+		}
+		slices.Reverse(path)
+		paths[g] = path
+	}
+	return paths
+}
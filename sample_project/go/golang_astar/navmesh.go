@@ -0,0 +1,189 @@
+package golang_astar
+
+import "math"
+
+// NodeF is a point in a continuous world
+type NodeF struct {
+	X, Y float64
+}
+
+// dist returns the straight-line distance between p and q
+func (p NodeF) dist(q NodeF) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// triarea2 returns twice the signed area of the triangle abc, positive when
+// c lies to the right of the line from a to b
+func triarea2(a, b, c NodeF) float64 {
+	return (c.X-a.X)*(b.Y-a.Y) - (b.X-a.X)*(c.Y-a.Y)
+}
+
+// navCostScale converts distances to Costs for the A* core, keeping three
+// decimal places
+const navCostScale = 1000
+
+// NavMesh is a navigation mesh: convex polygons covering the walkable
+// floor, each listing its vertices counter-clockwise with X to the right
+// and Y up. Polygons are neighbors when they share an edge, which must use
+// exactly the same two vertices in both.
+type NavMesh struct {
+	Polygons [][]NodeF
+
+	centroids []NodeF
+	links     [][]navLink
+}
+
+// navLink is an edge shared with a neighboring polygon, with left and right
+// as seen when crossing it from the polygon that lists it
+type navLink struct {
+	to          int
+	left, right NodeF
+}
+
+// NewNavMesh creates a navigation mesh from polygons, finding the edges
+// they share
+func NewNavMesh(polygons [][]NodeF) *NavMesh {
+	m := &NavMesh{
+		Polygons:  polygons,
+		centroids: make([]NodeF, len(polygons)),
+		links:     make([][]navLink, len(polygons)),
+	}
+
+	edges := make(map[[2]NodeF]int)
+	for i, poly := range polygons {
+		var c NodeF
+		for j, v := range poly {
+			c.X += v.X / float64(len(poly))
+			c.Y += v.Y / float64(len(poly))
+			edges[[2]NodeF{v, poly[(j+1)%len(poly)]}] = i
+		}
+		m.centroids[i] = c
+	}
+	for i, poly := range polygons {
+		for j, a := range poly {
+			b := poly[(j+1)%len(poly)]
+			// the neighbor lists the same edge in the opposite direction
+			if k, ok := edges[[2]NodeF{b, a}]; ok && k != i {
+				m.links[i] = append(m.links[i], navLink{to: k, left: b, right: a})
+			}
+		}
+	}
+	return m
+}
+
+// Locate returns the index of the polygon containing p, or -1 if p is off
+// the mesh
+func (m *NavMesh) Locate(p NodeF) int {
+	for i, poly := range m.Polygons {
+		inside := len(poly) >= 3
+		for j, a := range poly {
+			if triarea2(a, poly[(j+1)%len(poly)], p) > 0 {
+				inside = false
+				break
+			}
+		}
+		if inside {
+			return i
+		}
+	}
+	return -1
+}
+
+// FindPath finds a path across the mesh from start to goal, returning its
+// corner points and length. Polygons are searched with FindPathG, moving
+// between neighbors at the cost of the distance between their centroids,
+// and the resulting corridor is pulled tight with the funnel algorithm. It
+// returns nil if either point is off the mesh or they are not connected.
+func (m *NavMesh) FindPath(start, goal NodeF) ([]NodeF, float64) {
+	from, to := m.Locate(start), m.Locate(goal)
+	if from < 0 || to < 0 {
+		return nil, 0
+	}
+
+	polys, _ := FindPathG(from, to,
+		func(p int) []ArcG[int] {
+			arcs := make([]ArcG[int], len(m.links[p]))
+			for i, l := range m.links[p] {
+				d := m.centroids[p].dist(m.centroids[l.to])
+				arcs[i] = ArcG[int]{l.to, Cost(math.Ceil(d * navCostScale))}
+			}
+			return arcs
+		},
+		func(a, b int) Cost {
+			return Cost(m.centroids[a].dist(m.centroids[b]) * navCostScale)
+		})
+	if polys == nil {
+		return nil, 0
+	}
+
+	// the portals are the shared edges along the corridor, closed off by
+	// the goal itself
+	portals := make([][2]NodeF, 0, len(polys))
+	portals = append(portals, [2]NodeF{start, start})
+	for i := 1; i < len(polys); i++ {
+		for _, l := range m.links[polys[i-1]] {
+			if l.to == polys[i] {
+				portals = append(portals, [2]NodeF{l.left, l.right})
+				break
+			}
+		}
+	}
+	portals = append(portals, [2]NodeF{goal, goal})
+
+	path := funnel(portals)
+	var length float64
+	for i := 1; i < len(path); i++ {
+		length += path[i-1].dist(path[i])
+	}
+	return path, length
+}
+
+// funnel pulls a path tight through a sequence of left and right portal
+// points with the simple stupid funnel algorithm. The first portal is the
+// start and the last the goal, both given as zero-width portals.
+func funnel(portals [][2]NodeF) []NodeF {
+	apex, left, right := portals[0][0], portals[0][0], portals[0][1]
+	apexIndex, leftIndex, rightIndex := 0, 0, 0
+	path := []NodeF{apex}
+
+	for i := 1; i < len(portals); i++ {
+		l, r := portals[i][0], portals[i][1]
+
+		// tighten the right side of the funnel
+		if triarea2(apex, right, r) <= 0 {
+			if apex == right || triarea2(apex, left, r) > 0 {
+				right, rightIndex = r, i
+			} else {
+				// right crossed over left: left becomes a corner
+				if path[len(path)-1] != left {
+					path = append(path, left)
+				}
+				apex, apexIndex = left, leftIndex
+				right, rightIndex = apex, apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+
+		// tighten the left side of the funnel
+		if triarea2(apex, left, l) >= 0 {
+			if apex == left || triarea2(apex, right, l) < 0 {
+				left, leftIndex = l, i
+			} else {
+				// left crossed over right: right becomes a corner
+				if path[len(path)-1] != right {
+					path = append(path, right)
+				}
+				apex, apexIndex = right, rightIndex
+				left, leftIndex = apex, apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+	}
+
+	if goal := portals[len(portals)-1][0]; path[len(path)-1] != goal {
+		path = append(path, goal)
+	}
+	return path
+}
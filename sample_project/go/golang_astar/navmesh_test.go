@@ -0,0 +1,66 @@
+package golang_astar
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// lMesh is an L-shaped floor: a corner square with one arm running right
+// and one running up, leaving the square (2,2)-(6,6) open as a notch
+func lMesh() *NavMesh {
+	return NewNavMesh([][]NodeF{
+		{{0, 0}, {2, 0}, {2, 2}, {0, 2}},
+		{{2, 0}, {6, 0}, {6, 2}, {2, 2}},
+		{{0, 2}, {2, 2}, {2, 6}, {0, 6}},
+	})
+}
+
+func TestNavMeshLocate(t *testing.T) {
+	m := lMesh()
+	tests := []struct {
+		p    NodeF
+		want []int
+	}{
+		{NodeF{1, 1}, []int{0}},
+		{NodeF{4, 1}, []int{1}},
+		{NodeF{1, 4}, []int{2}},
+		{NodeF{4, 0}, []int{1}},    // on the outer edge
+		{NodeF{2, 1}, []int{0, 1}}, // on the edge the corner shares with an arm
+		{NodeF{4, 4}, []int{-1}},   // in the notch
+		{NodeF{-1, 1}, []int{-1}},
+	}
+	for _, tt := range tests {
+		if got := m.Locate(tt.p); !slices.Contains(tt.want, got) {
+			t.Errorf("Locate(%v) = %d, want one of %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestNavMeshFindPathHugsInnerCorner(t *testing.T) {
+	m := lMesh()
+	start, goal := NodeF{5, 1}, NodeF{1, 5}
+	path, length := m.FindPath(start, goal)
+	if want := []NodeF{start, {2, 2}, goal}; !slices.Equal(path, want) {
+		t.Fatalf("FindPath = %v, want %v round the inner corner", path, want)
+	}
+	if want := 2 * math.Sqrt(10); math.Abs(length-want) > 1e-9 {
+		t.Errorf("length = %v, want %v", length, want)
+	}
+
+	if path, _ := m.FindPath(start, NodeF{4, 4}); path != nil {
+		t.Errorf("FindPath into the notch = %v, want nil", path)
+	}
+}
+
+func TestNavMeshFindPathSamePolygon(t *testing.T) {
+	m := lMesh()
+	start, goal := NodeF{0.5, 0.5}, NodeF{1.5, 1.5}
+	path, length := m.FindPath(start, goal)
+	if want := []NodeF{start, goal}; !slices.Equal(path, want) {
+		t.Errorf("FindPath = %v, want the straight segment %v", path, want)
+	}
+	if want := math.Sqrt(2); math.Abs(length-want) > 1e-9 {
+		t.Errorf("length = %v, want %v", length, want)
+	}
+}
@@ -1,6 +1,10 @@
 package golang_astar
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"slices"
+)
 
 // Node represents a position in the grid
 type Node struct {
@@ -17,10 +21,80 @@ func (n Node) Equal(other Node) bool {
 	return n.X == other.X && n.Y == other.Y
 }
 
+// Add returns the node dx columns and dy rows away from n
+func (n Node) Add(dx, dy int) Node {
+	return Node{n.X + dx, n.Y + dy}
+}
+
+// Sub returns the step from other to n
+func (n Node) Sub(other Node) Node {
+	return Node{n.X - other.X, n.Y - other.Y}
+}
+
+// Single steps in each direction. Y grows downward, so North is {0, -1}.
+var (
+	North     = Node{0, -1}
+	South     = Node{0, 1}
+	East      = Node{1, 0}
+	West      = Node{-1, 0}
+	NorthEast = Node{1, -1}
+	NorthWest = Node{-1, -1}
+	SouthEast = Node{1, 1}
+	SouthWest = Node{-1, 1}
+)
+
+// directions8 and directions4 list the steps in the order the grid tries
+// them, which decides between paths of equal cost
+var (
+	directions8 = []Node{NorthWest, West, SouthWest, North, South, NorthEast, East, SouthEast}
+	directions4 = []Node{West, North, South, East}
+)
+
+// Directions8 returns the steps to all eight adjacent cells
+func Directions8() []Node {
+	return slices.Clone(directions8)
+}
+
+// Directions4 returns the steps to the four orthogonally adjacent cells
+func Directions4() []Node {
+	return slices.Clone(directions4)
+}
+
+// less orders nodes by X and then by Y
+func (n Node) less(other Node) bool {
+	if n.X != other.X {
+		return n.X < other.X
+	}
+	return n.Y < other.Y
+}
+
 // Cost represents the cost to move between nodes
 type Cost int
 
-// Arc represents a connection between nodes with an associated cost
+// MaxCost is the largest representable Cost. The searches saturate at
+// MaxCost rather than wrapping around, so on 32-bit platforms very large
+// weighted grids report MaxCost instead of a bogus small cost.
+const MaxCost = Cost(math.MaxInt)
+
+// addCost adds two costs, saturating at MaxCost
+func addCost(a, b Cost) Cost {
+	if b > 0 && a > MaxCost-b {
+		return MaxCost
+	}
+	return a + b
+}
+
+// mulCost multiplies two costs, saturating at MaxCost
+func mulCost(a, b Cost) Cost {
+	if a > 0 && b > MaxCost/a {
+		return MaxCost
+	}
+	return a * b
+}
+
+// Arc represents a connection between nodes with an associated cost. Arcs
+// are one-way: the move back from To is a separate arc, which may cost
+// something else or not exist at all.
 type Arc struct {
 	To   Node
 	Cost Cost
@@ -28,31 +102,31 @@ type Arc struct {
 
 // Heuristic calculates estimated cost to reach another node
 func (n Node) Heuristic(from Node) int {
-	dx := n.X - from.X
-	if dx < 0 {
-		dx = -dx
-	}
-	dy := n.Y - from.Y
-	if dy < 0 {
-		dy = -dy
-	}
-	if dx > dy {
-		return dx
-	}
-	return dy
+	return n.ChebyshevDistance(from)
+}
+
+// ManhattanDistance returns the number of orthogonal steps between n and
+// other
+func (n Node) ManhattanDistance(other Node) int {
+	return abs(n.X-other.X) + abs(n.Y-other.Y)
+}
+
+// EuclideanDistance returns the straight-line distance between n and other
+func (n Node) EuclideanDistance(other Node) float64 {
+	return math.Hypot(float64(n.X-other.X), float64(n.Y-other.Y))
+}
+
+// ChebyshevDistance returns the number of steps between n and other when
+// diagonal steps are allowed
+func (n Node) ChebyshevDistance(other Node) int {
+	return max(abs(n.X-other.X), abs(n.Y-other.Y))
 }
 
 // To returns list of arcs from this node to neighbors
 func (n Node) To() []Arc {
 	neighbors := make([]Arc, 0, 8)
-	for dx := -1; dx <= 1; dx++ {
-		for dy := -1; dy <= 1; dy++ {
-			if dx == 0 && dy == 0 {
-				continue
-			}
-			next := Node{n.X + dx, n.Y + dy}
-			neighbors = append(neighbors, Arc{To: next, Cost: 1})
-		}
+	for _, d := range directions8 {
+		neighbors = append(neighbors, Arc{To: n.Add(d.X, d.Y), Cost: 1})
 	}
 	return neighbors
 }
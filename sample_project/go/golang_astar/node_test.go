@@ -0,0 +1,17 @@
+package golang_astar
+
+import "testing"
+
+func TestHeuristicsAgree(t *testing.T) {
+	for x := -6; x <= 6; x++ {
+		for y := -6; y <= 6; y++ {
+			a, b := Node{x, y}, Node{y - 1, 2 * x}
+			if got, want := Heuristic(a, b), Cost(b.Heuristic(a)); got != want {
+				t.Errorf("Heuristic(%v, %v) = %d, Node.Heuristic = %d", a, b, got, want)
+			}
+			if got, want := Heuristic(a, b), Heuristic(b, a); got != want {
+				t.Errorf("Heuristic(%v, %v) = %d, reversed %d", a, b, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package golang_astar
+
+import (
+	"errors"
+	"fmt"
+)
+
+// arcCost returns the cost of the cheapest move directly from a to b,
+// reporting false if the grid has no such move
+func (g *Grid) arcCost(a, b Node) (Cost, bool) {
+	cost, found := Cost(0), false
+	for _, arc := range g.Neighbors(a) {
+		if arc.To == b && (!found || arc.Cost < cost) {
+			cost, found = arc.Cost, true
+		}
+	}
+	return cost, found
+}
+
+// PathCost sums the cost of walking path on the grid, failing if a node is
+// outside the grid or two consecutive nodes are not joined by a move
+func (g *Grid) PathCost(path []Node) (Cost, error) {
+	var total Cost
+	for i, n := range path {
+		if !g.IsValidPosition(n) {
+			return 0, fmt.Errorf("astar: node %v at index %d is outside the grid", n, i)
+		}
+		if i == 0 {
+			continue
+		}
+		cost, ok := g.arcCost(path[i-1], n)
+		if !ok {
+			return 0, fmt.Errorf("astar: no move from %v to %v at index %d", path[i-1], n, i)
+		}
+		total = addCost(total, cost)
+	}
+	return total, nil
+}
+
+// ValidatePath checks that path is a walk on the grid: it is not empty,
+// every node is inside the grid and every step is a move the grid allows
+// under its connectivity, corner rules and portals. The error names the
+// index of the first offending node, to catch gaps left by code that builds
+// or edits paths.
+func (g *Grid) ValidatePath(path []Node) error {
+	if len(path) == 0 {
+		return errors.New("astar: path is empty")
+	}
+	_, err := g.PathCost(path)
+	return err
+}
+
+// PathIsValid reports whether path can still be walked on the grid as it
+// is now: every node must be open, not a barrier or impassable, and every
+// step must be a move the grid allows. Paths found before barriers were
+// added can be checked with it to decide whether to replan. An empty path
+// is not valid.
+func (g *Grid) PathIsValid(path []Node) bool {
+	for _, n := range path {
+		if g.blocked(n) {
+			return false
+		}
+	}
+	return g.ValidatePath(path) == nil
+}
+
+// checkPath checks the promises every search makes about a path it
+// returns: it starts at start and ends at goal, each step is a move the
+// grid allows, and the moves add up to cost. It returns an error naming the
+// first one broken.
+func (g *Grid) checkPath(path []Node, start, goal Node, cost Cost) error {
+	if err := g.ValidatePath(path); err != nil {
+		return err
+	}
+	if path[0] != start {
+		return fmt.Errorf("astar: path starts at %v, want %v", path[0], start)
+	}
+	if last := path[len(path)-1]; last != goal {
+		return fmt.Errorf("astar: path ends at %v, want %v", last, goal)
+	}
+	if total, _ := g.PathCost(path); total != cost {
+		return fmt.Errorf("astar: path costs %d, want %d", total, cost)
+	}
+	return nil
+}
+
+// PathSteps returns the number of moves in path, which is not its cost
+// when steps are weighted
+func PathSteps(path []Node) int {
+	return max(len(path)-1, 0)
+}
+
+// PathMetrics returns the number of moves in path, its cost and how many of
+// the moves are diagonal steps, for movement-point accounting. The cost is
+// the one PathCost reports, or 0 if the grid cannot walk the path.
+func (g *Grid) PathMetrics(path []Node) (steps int, cost Cost, diagonals int) {
+	for i := 1; i < len(path); i++ {
+		if d := path[i].Sub(path[i-1]); abs(d.X) == 1 && abs(d.Y) == 1 {
+			diagonals++
+		}
+	}
+	cost, _ = g.PathCost(path)
+	return PathSteps(path), cost, diagonals
+}
+
+// FindPathArcs finds the shortest path between start and goal like
+// FindPath, but returns the moves along it with the cost of each, to show
+// where expensive terrain was crossed. The first arc leads to the node after
+// start; a path from a node to itself has no arcs.
+func FindPathArcs(grid *Grid, start, goal Node) ([]Arc, Cost) {
+	path, cost := FindPath(grid, start, goal)
+	if path == nil {
+		return nil, 0
+	}
+	arcs := make([]Arc, 0, len(path)-1)
+	for i := 1; i < len(path); i++ {
+		step, _ := grid.arcCost(path[i-1], path[i])
+		arcs = append(arcs, Arc{path[i], step})
+	}
+	return arcs, cost
+}
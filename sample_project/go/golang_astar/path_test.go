@@ -0,0 +1,55 @@
+package golang_astar
+
+import "testing"
+
+func TestPathCost(t *testing.T) {
+	g := NewGrid(5, 5)
+	g.Weights[Node{2, 0}] = 3
+	g.Barriers[Node{1, 1}] = true
+
+	tests := []struct {
+		name    string
+		path    []Node
+		cost    Cost
+		wantErr bool
+	}{
+		{"single node", []Node{{0, 0}}, 0, false},
+		{"weighted", []Node{{0, 0}, {1, 0}, {2, 0}}, 4, false},
+		{"barrier", []Node{{0, 0}, {1, 1}}, 100, false},
+		{"gap", []Node{{0, 0}, {1, 0}, {3, 0}}, 0, true},
+		{"off grid", []Node{{0, 0}, {-1, 0}}, 0, true},
+	}
+	for _, tt := range tests {
+		cost, err := g.PathCost(tt.path)
+		if (err != nil) != tt.wantErr || cost != tt.cost {
+			t.Errorf("%s: PathCost(%v) = %d, %v; want %d, error %t",
+				tt.name, tt.path, cost, err, tt.cost, tt.wantErr)
+		}
+	}
+
+	path, want := FindPath(g, Node{0, 0}, Node{4, 4})
+	if cost, err := g.PathCost(path); err != nil || cost != want {
+		t.Errorf("PathCost(FindPath) = %d, %v; want %d", cost, err, want)
+	}
+}
+
+func TestPathIsValidAfterBarrierChange(t *testing.T) {
+	g := NewGrid(6, 6)
+	path, _ := FindPath(g, Node{0, 0}, Node{5, 5})
+	if !g.PathIsValid(path) {
+		t.Fatalf("fresh path %v is not valid", path)
+	}
+
+	g.SetBarrier(path[2])
+	if g.PathIsValid(path) {
+		t.Errorf("path %v still valid with a barrier on %v", path, path[2])
+	}
+	g.ClearBarrier(path[2])
+	if !g.PathIsValid(path) {
+		t.Errorf("path %v not valid once the barrier is cleared", path)
+	}
+
+	if g.PathIsValid(nil) {
+		t.Error("empty path is valid")
+	}
+}
@@ -0,0 +1,18 @@
+package golang_astar
+
+// FindPathToPredicate finds the shortest path from start to the nearest node
+// for which isGoal returns true, such as the closest cell holding a
+// resource. Since the goal is not known up front, h estimates the remaining
+// cost from a node on its own; it must never overestimate the cost to the
+// nearest goal, and a nil h searches without an estimate, like Dijkstra.
+func FindPathToPredicate(grid *Grid, start Node, isGoal func(Node) bool, h func(Node) Cost) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) {
+		return nil, 0
+	}
+	opts := searchOptions{isGoal: isGoal}
+	if h != nil {
+		opts.h = func(n, _ Node) Cost { return h(n) }
+	}
+	path, cost, _ := search(grid, start, start, opts)
+	return path, cost
+}
@@ -0,0 +1,26 @@
+package golang_astar
+
+// Reachable returns every cell that can be reached from start for at most
+// maxCost, mapped to the cheapest cost of reaching it, such as the movement
+// range of a unit. start itself is included at cost 0 unless it lies
+// outside the grid, in which case the result is nil.
+func (g *Grid) Reachable(start Node, maxCost Cost) map[Node]Cost {
+	if !g.IsValidPosition(start) || maxCost < 0 {
+		return nil
+	}
+	var s Searcher
+	s.search(g, start, start, searchOptions{
+		isGoal: never,
+		successors: func(current *searchNode) []Arc {
+			arcs := g.Neighbors(current.pos)
+			within := make([]Arc, 0, len(arcs))
+			for _, arc := range arcs {
+				if addCost(current.g, arc.Cost) <= maxCost {
+					within = append(within, arc)
+				}
+			}
+			return within
+		},
+	})
+	return s.settled()
+}
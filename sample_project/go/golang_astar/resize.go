@@ -0,0 +1,122 @@
+package golang_astar
+
+// Resize changes the size of the grid. Barriers, impassable cells, weights,
+// exit costs, danger and portals inside the new bounds are kept and the rest
+// are dropped. The neighbor cache, if any, is dropped too.
+func (g *Grid) Resize(newWidth, newHeight int) {
+	old := *g
+	g.Width, g.Height = newWidth, newHeight
+	g.neighbors = nil
+
+	if old.barrierBits != nil {
+		g.barrierBits = make([]uint64, (max(newWidth*newHeight, 0)+63)/64)
+		for x := 0; x < min(old.Width, newWidth); x++ {
+			for y := 0; y < min(old.Height, newHeight); y++ {
+				if n := (Node{x, y}); old.IsBarrier(n) {
+					g.SetBarrier(n)
+				}
+			}
+		}
+	}
+	pruneCells(g, g.Barriers)
+	pruneCells(g, g.Impassable)
+	pruneCells(g, g.Weights)
+	pruneCells(g, g.ExitCosts)
+	pruneCells(g, g.Danger)
+	for from, portals := range g.Portals {
+		if !g.IsValidPosition(from) {
+			delete(g.Portals, from)
+			continue
+		}
+		kept := portals[:0]
+		for _, p := range portals {
+			if g.IsValidPosition(p.To) {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == 0 {
+			delete(g.Portals, from)
+		} else {
+			g.Portals[from] = kept
+		}
+	}
+}
+
+// pruneCells deletes the entries of m that lie outside the grid
+func pruneCells[V any](g *Grid, m map[Node]V) {
+	for n := range m {
+		if !g.IsValidPosition(n) {
+			delete(m, n)
+		}
+	}
+}
+
+// SubGrid copies the region from (x0, y0) up to but not including (x1, y1)
+// into a new grid whose Node{0, 0} is the grid's Node{x0, y0}, clipped to
+// the grid. Cells, portals with both ends inside the region and movement
+// settings are copied; NeighborFunc and AllowedDirections are wrapped to
+// translate coordinates, and moves NeighborFunc makes out of the region are
+// dropped. Add Node{x0, y0} to nodes of the sub-grid, for
+// example with n.Add(x0, y0), to get back to the grid's coordinates.
+func (g *Grid) SubGrid(x0, y0, x1, y1 int) *Grid {
+	x0, y0 = max(x0, 0), max(y0, 0)
+	x1, y1 = min(x1, g.Width), min(y1, g.Height)
+	width, height := max(x1-x0, 0), max(y1-y0, 0)
+
+	var sub *Grid
+	if g.barrierBits != nil {
+		sub = NewDenseGrid(width, height)
+	} else {
+		sub = NewGrid(width, height)
+	}
+	sub.Connectivity = g.Connectivity
+	sub.OrthogonalCost, sub.DiagonalCost = g.OrthogonalCost, g.DiagonalCost
+	sub.MinEdgeCost = g.MinEdgeCost
+	sub.WallProximityPenalty = g.WallProximityPenalty
+	sub.AllowCornerCutting = g.AllowCornerCutting
+	sub.DiagonalPolicy = g.DiagonalPolicy
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			n, src := Node{x, y}, Node{x0 + x, y0 + y}
+			if g.IsBarrier(src) {
+				sub.SetBarrier(n)
+			}
+			if g.Impassable[src] {
+				sub.Impassable[n] = true
+			}
+			if w, ok := g.Weights[src]; ok {
+				sub.Weights[n] = w
+			}
+			if c, ok := g.ExitCosts[src]; ok {
+				sub.ExitCosts[n] = c
+			}
+			if c, ok := g.Danger[src]; ok {
+				sub.Danger[n] = c
+			}
+			for _, p := range g.Portals[src] {
+				sub.AddPortal(n, p.To.Add(-x0, -y0), p.Cost)
+			}
+		}
+	}
+
+	if g.NeighborFunc != nil {
+		sub.NeighborFunc = func(n Node) []Arc {
+			arcs := g.NeighborFunc(n.Add(x0, y0))
+			moved := make([]Arc, 0, len(arcs))
+			for _, arc := range arcs {
+				// moves leaving the region have nowhere to land
+				if to := arc.To.Add(-x0, -y0); sub.IsValidPosition(to) {
+					moved = append(moved, Arc{to, arc.Cost})
+				}
+			}
+			return moved
+		}
+	}
+	if g.AllowedDirections != nil {
+		sub.AllowedDirections = func(n Node) []Node {
+			return g.AllowedDirections(n.Add(x0, y0))
+		}
+	}
+	return sub
+}
@@ -0,0 +1,78 @@
+package golang_astar
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	g := NewGrid(10, 10)
+	g.Barriers[Node{3, 3}] = true
+	g.Barriers[Node{9, 9}] = true
+	g.Weights[Node{8, 1}] = 4
+	g.Precompute()
+
+	g.Resize(20, 20)
+	if want := map[Node]bool{{3, 3}: true, {9, 9}: true}; !maps.Equal(g.Barriers, want) {
+		t.Errorf("after growing: barriers = %v, want %v", g.Barriers, want)
+	}
+	// the cache built for 10x10 would leave the new cells without moves
+	if path, cost := FindPath(g, Node{0, 0}, Node{19, 19}); path == nil {
+		t.Errorf("after growing: FindPath = %v, %d; want a path into the new area", path, cost)
+	}
+
+	g.Resize(5, 5)
+	if want := map[Node]bool{{3, 3}: true}; !maps.Equal(g.Barriers, want) {
+		t.Errorf("after shrinking: barriers = %v, want %v", g.Barriers, want)
+	}
+	if len(g.Weights) != 0 {
+		t.Errorf("after shrinking: weights = %v, want none", g.Weights)
+	}
+}
+
+func TestSubGrid(t *testing.T) {
+	g := NewGrid(10, 10)
+	g.Barriers[Node{4, 4}] = true
+	g.Weights[Node{5, 3}] = 3
+	if err := g.AddPortal(Node{3, 3}, Node{5, 5}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddPortal(Node{3, 3}, Node{9, 9}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	sub := g.SubGrid(3, 3, 7, 7)
+	if sub.Width != 4 || sub.Height != 4 {
+		t.Fatalf("size = %dx%d, want 4x4", sub.Width, sub.Height)
+	}
+	if !sub.IsBarrier(Node{1, 1}) || sub.Weights[Node{2, 0}] != 3 {
+		t.Errorf("cells not carried over: barriers %v, weights %v", sub.barrierList(), sub.Weights)
+	}
+	if want := []Arc{{Node{2, 2}, 0}}; !slices.Equal(sub.Portals[Node{0, 0}], want) {
+		t.Errorf("portals = %v, want only %v out of (0,0)", sub.Portals, want)
+	}
+}
+
+func TestSubGridNeighborFuncStaysInside(t *testing.T) {
+	g := NewGrid(10, 10)
+	g.NeighborFunc = func(n Node) []Arc {
+		arcs := g.GetNeighbors(n)
+		// a teleport reaching outside any small region
+		return append(arcs, Arc{Node{9, 9}, 0})
+	}
+
+	sub := g.SubGrid(2, 2, 5, 5)
+	for x := 0; x < sub.Width; x++ {
+		for y := 0; y < sub.Height; y++ {
+			for _, arc := range sub.Neighbors(Node{x, y}) {
+				if !sub.IsValidPosition(arc.To) {
+					t.Fatalf("move from %v leaves the sub-grid to %v", Node{x, y}, arc.To)
+				}
+			}
+		}
+	}
+	if path, cost := FindPath(sub, Node{0, 0}, Node{2, 2}); cost != 2 {
+		t.Errorf("FindPath = %v, %d; want cost 2", path, cost)
+	}
+}
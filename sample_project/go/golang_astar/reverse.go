@@ -0,0 +1,26 @@
+package golang_astar
+
+import "slices"
+
+// FindPathReverse finds the shortest path between start and goal by
+// searching backward from goal along the moves leading into each cell, and
+// returns it in forward order, from start to goal. Moves keep their forward
+// direction and cost, so one-way cells, exit costs and other asymmetric
+// rules are honored and the cost always matches FindPath's. What changes is
+// where the search spends its effort: it grows from goal instead of start,
+// and among paths of equal cost it may settle on a different one than
+// FindPath. Moves made by a NeighborFunc are only seen between adjacent
+// cells.
+func FindPathReverse(grid *Grid, start, goal Node) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+	path, cost, _ := search(grid, goal, start, searchOptions{
+		h: defaultHeuristic(grid),
+		successors: func(current *searchNode) []Arc {
+			return grid.predecessors(current.pos)
+		},
+	})
+	slices.Reverse(path)
+	return path, cost
+}
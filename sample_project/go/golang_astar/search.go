@@ -2,6 +2,7 @@ package golang_astar
 
 import (
 	"container/heap"
+	"context"
 )
 
 // node represents a node in the search path
@@ -9,14 +10,28 @@ type searchNode struct {
 	pos     Node
 	parent  *searchNode
 	g, h, f Cost
-	index   int // for heap.Interface
+	tie     [2]int // orders nodes of equal f, zero unless breaking ties
+	index   int    // for heap.Interface
 }
 
 // nodeHeap implements heap.Interface
 type nodeHeap []*searchNode
 
-func (h nodeHeap) Len() int           { return len(h) }
-func (h nodeHeap) Less(i, j int) bool { return h[i].f < h[j].f }
+func (h nodeHeap) Len() int { return len(h) }
+func (h nodeHeap) Less(i, j int) bool {
+	a, b := h[i], h[j]
+	if a.f != b.f {
+		return a.f < b.f
+	}
+	if a.tie[0] != b.tie[0] {
+		return a.tie[0] < b.tie[0]
+	}
+	if a.tie[1] != b.tie[1] {
+		return a.tie[1] < b.tie[1]
+	}
+	// Fall back to position so equal nodes always pop in the same order
+	return a.pos.less(b.pos)
+}
 func (h nodeHeap) Swap(i, j int) {
 	h[i], h[j] = h[j], h[i]
 	h[i].index = i
@@ -38,86 +53,181 @@ func (h *nodeHeap) Pop() interface{} {
 	return item
 }
 
-// Heuristic estimates remaining cost to goal
+// Heuristic estimates remaining cost to goal. It is the Chebyshev distance,
+// the same estimate as Node.Heuristic.
 func Heuristic(current, goal Node) Cost {
-	dx := current.X - goal.X
-	if dx < 0 {
-		dx = -dx
+	return Cost(current.ChebyshevDistance(goal))
+}
+
+// zeroHeuristic never estimates any remaining cost
+func zeroHeuristic(a, b Node) Cost {
+	return 0
+}
+
+// FindPath finds the shortest path between start and goal. When start and
+// goal are the same node the path is just that node, at no cost. If either
+// lies outside a graph that has bounds, such as a Grid, it returns nil
+// without searching; FindPathE reports which one.
+func FindPath(graph Graph, start, goal Node) ([]Node, Cost) {
+	if outOfBounds(graph, start, goal) {
+		return nil, 0
 	}
-	dy := current.Y - goal.Y
-	if dy < 0 {
-		dy = -dy
+	if start == goal {
+		return []Node{start}, 0
 	}
-	if dx > dy {
-		return Cost(dx)
+	return FindPathWithHeuristic(graph, start, goal, defaultHeuristic(graph))
+}
+
+// FindPathWithHeuristic finds the shortest path between start and goal,
+// using h to estimate the remaining cost. A nil h is treated as the zero
+// heuristic, which turns the search into Dijkstra's algorithm. Like
+// FindPath, it returns nil if start or goal lies outside the graph.
+func FindPathWithHeuristic(graph Graph, start, goal Node, h func(a, b Node) Cost) ([]Node, Cost) {
+	if outOfBounds(graph, start, goal) {
+		return nil, 0
 	}
-	return Cost(dy)
+	path, cost, _ := search(graph, start, goal, searchOptions{h: h})
+	return path, cost
 }
 
-// FindPath finds the shortest path between start and goal
-func FindPath(grid *Grid, start, goal Node) ([]Node, Cost) {
-	openSet := &nodeHeap{}
+// searchOptions tunes a single run of search
+type searchOptions struct {
+	h     func(a, b Node) Cost
+	stats *SearchStats
+	ctx   context.Context // checked every cancelCheckInterval expansions
+
+	// isGoal, when set, replaces the equality test against goal
+	isGoal func(n Node) bool
+
+	// successors, when set, replaces graph.Neighbors as the source of arcs
+	// out of an expanded node
+	successors func(current *searchNode) []Arc
+
+	// onExpand is told about every node popped from the open set
+	onExpand func(n Node, g, h, f Cost)
+
+	// noReopen leaves closed nodes closed even when a cheaper way to them
+	// turns up, which only happens with an inconsistent heuristic
+	noReopen bool
+
+	// maxExpansions, when positive, caps how many nodes are popped before
+	// the search gives up with errBudgetExhausted
+	maxExpansions int
+}
+
+// cancelCheckInterval is how many expansions pass between context checks
+const cancelCheckInterval = 256
+
+// search runs A* from start to goal, shared by all FindPath variants.
+// It only fails when opts.ctx is cancelled or opts.maxExpansions runs out.
+func (s *Searcher) search(graph Graph, start, goal Node, opts searchOptions) ([]Node, Cost, error) {
+	s.reset()
+	h := opts.h
+	if h == nil {
+		h = zeroHeuristic
+	}
+	stats := opts.stats
+	if stats == nil {
+		stats = &s.stats
+	}
+	isGoal := opts.isGoal
+	if isGoal == nil {
+		isGoal = goal.Equal
+	}
+	successors := opts.successors
+	if successors == nil {
+		successors = func(current *searchNode) []Arc {
+			return graph.Neighbors(current.pos)
+		}
+	}
+
+	openSet := &s.open
 	heap.Init(openSet)
 
-	startNode := &searchNode{
+	startNode := s.newNode(searchNode{
 		pos:    start,
 		g:      0,
-		h:      Heuristic(start, goal),
+		h:      h(start, goal),
 		parent: nil,
-	}
-	startNode.f = startNode.g + startNode.h
+	})
+	startNode.f = addCost(startNode.g, startNode.h)
 	heap.Push(openSet, startNode)
+	stats.generated(openSet.Len())
 
-	closedSet := make(map[Node]*searchNode)
+	// openIndex mirrors openSet so neighbors can be found without a scan
+	openIndex := s.openIndex
+	openIndex[start] = startNode
+	closedSet := s.closedSet
 
-	for openSet.Len() > 0 {
+	for expanded := 0; openSet.Len() > 0; expanded++ {
+		if opts.maxExpansions > 0 && expanded == opts.maxExpansions {
+			return nil, 0, errBudgetExhausted
+		}
+		if s.OnStep != nil {
+			s.OnStep(s.frontier())
+		}
 		current := heap.Pop(openSet).(*searchNode)
-
-		if current.pos.Equal(goal) {
-			// Reconstruct path
-			path := []Node{}
-			cost := current.g
-			for current != nil {
-				path = append([]Node{current.pos}, path...)
-				current = current.parent
+		delete(openIndex, current.pos)
+		stats.NodesExpanded++
+		if opts.ctx != nil && stats.NodesExpanded%cancelCheckInterval == 0 {
+			if err := opts.ctx.Err(); err != nil {
+				return nil, 0, err
 			}
-			return path, cost
+		}
+		if opts.onExpand != nil {
+			opts.onExpand(current.pos, current.g, current.h, current.f)
+		}
+		if s.best == nil || current.h < s.best.h || current.h == s.best.h && current.g < s.best.g {
+			s.best = current
 		}
 
-		closedSet[current.pos] = current
+		if isGoal(current.pos) {
+			return current.path(), current.g, nil
+		}
 
-		for _, arc := range grid.GetNeighbors(current.pos) {
-			if _, exists := closedSet[arc.To]; exists {
-				continue
-			}
+		closedSet[current.pos] = current
 
-			g := current.g + arc.Cost
+		for _, arc := range successors(current) {
+			g := addCost(current.g, arc.Cost)
 
-			var neighbor *searchNode
-			for _, node := range *openSet {
-				if node.pos.Equal(arc.To) {
-					neighbor = node
-					break
+			// An inconsistent heuristic can close a node before its cheapest
+			// path is known; reopen it so the result stays optimal
+			if closed, exists := closedSet[arc.To]; exists {
+				if opts.noReopen || g >= closed.g {
+					continue
 				}
+				delete(closedSet, arc.To)
+				closed.parent = current
+				closed.g = g
+				closed.f = addCost(g, closed.h)
+				heap.Push(openSet, closed)
+				openIndex[arc.To] = closed
+				continue
 			}
 
+			neighbor := openIndex[arc.To]
 			if neighbor == nil {
-				neighbor = &searchNode{
+				neighbor = s.newNode(searchNode{
 					pos:    arc.To,
 					parent: current,
 					g:      g,
-					h:      Heuristic(arc.To, goal),
+					h:      h(arc.To, goal),
+				})
+				neighbor.f = addCost(neighbor.g, neighbor.h)
+				if s.BreakTies {
+					neighbor.tie = tieKey(neighbor, start, goal)
 				}
-				neighbor.f = neighbor.g + neighbor.h
 				heap.Push(openSet, neighbor)
+				openIndex[arc.To] = neighbor
+				stats.generated(openSet.Len())
 			} else if g < neighbor.g {
 				neighbor.parent = current
 				neighbor.g = g
-				neighbor.f = g + neighbor.h
+				neighbor.f = addCost(g, neighbor.h)
 				heap.Fix(openSet, neighbor.index)
 			}
 		}
 	}
 
-	return nil, 0 // No path found
+	return nil, 0, nil // No path found
 }
@@ -0,0 +1,162 @@
+package golang_astar
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentFindPath runs many searches over one shared grid at once.
+// Run it with -race to check they only read the grid.
+func TestConcurrentFindPath(t *testing.T) {
+	precomputed := NewRandomGrid(40, 40, 0.2, 30)
+	precomputed.Precompute()
+	dense := NewDenseGrid(40, 40)
+	dense.SetBarriers(NewRandomGrid(40, 40, 0.2, 30).barrierList())
+	dense.Precompute()
+
+	grids := map[string]*Grid{
+		"map":         NewRandomGrid(40, 40, 0.2, 30),
+		"precomputed": precomputed,
+		"dense":       dense,
+	}
+	for name, g := range grids {
+		t.Run(name, func(t *testing.T) {
+			start, goal := Node{0, 0}, Node{39, 39}
+			_, want := FindPath(g, start, goal)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 2; j++ {
+						if _, cost := FindPath(g, start, goal); cost != want {
+							t.Errorf("FindPath cost = %d, want %d", cost, want)
+						}
+						if _, cost := FindPathBidirectional(g, start, goal); cost != want {
+							t.Errorf("FindPathBidirectional cost = %d, want %d", cost, want)
+						}
+						FindPathJPS(g, start, goal)
+						FindPathThetaStar(g, start, goal)
+						g.FlowField(goal)
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestFindPathDeterministic(t *testing.T) {
+	// an open grid has many equally short paths to choose between
+	g := NewGrid(20, 20)
+	start, goal := Node{0, 0}, Node{19, 11}
+	want, _ := FindPath(g, start, goal)
+	for i := 0; i < 100; i++ {
+		if path, _ := FindPath(g, start, goal); !slices.Equal(path, want) {
+			t.Fatalf("run %d: FindPath = %v, want %v", i, path, want)
+		}
+	}
+}
+
+// arcGraph is a Graph listing the arcs out of each node
+type arcGraph map[Node][]Arc
+
+func (g arcGraph) Neighbors(n Node) []Arc { return g[n] }
+
+func TestFindPathReopensClosedNodes(t *testing.T) {
+	s, a, b, goal := Node{0, 0}, Node{1, 0}, Node{2, 0}, Node{3, 0}
+	g := arcGraph{
+		s: {{a, 1}, {b, 3}},
+		a: {{b, 1}},
+		b: {{goal, 5}},
+	}
+	// admissible but inconsistent: a looks far enough away that b is closed
+	// through the dear arc before the cheap route through a turns up
+	h := func(n, _ Node) Cost {
+		if n == a {
+			return 4
+		}
+		return 0
+	}
+
+	want := []Node{s, a, b, goal}
+	if path, cost := FindPathWithHeuristic(g, s, goal, h); cost != 7 || !slices.Equal(path, want) {
+		t.Errorf("FindPathWithHeuristic = %v, %d; want %v at cost 7", path, cost, want)
+	}
+}
+
+func TestFindPathStartIsGoal(t *testing.T) {
+	g := NewGrid(3, 3)
+	g.Barriers[Node{1, 1}] = true
+	for _, n := range []Node{{0, 0}, {1, 1}} {
+		if path, cost := FindPath(g, n, n); cost != 0 || !slices.Equal(path, []Node{n}) {
+			t.Errorf("FindPath(%v, %v) = %v, %d; want [%v], 0", n, n, path, cost, n)
+		}
+	}
+}
+
+// FuzzFindPath checks FindPath against Dijkstra on random grids, with
+// impassable walls so some goals are unreachable.
+func FuzzFindPath(f *testing.F) {
+	f.Add(int64(1), uint8(20), uint8(20), uint8(30), uint8(0), uint8(0), uint8(19), uint8(19))
+	f.Add(int64(7), uint8(5), uint8(40), uint8(60), uint8(4), uint8(0), uint8(0), uint8(39))
+	f.Fuzz(func(t *testing.T, seed int64, w, h, density, sx, sy, gx, gy uint8) {
+		width, height := int(w%32)+1, int(h%32)+1
+		g := NewRandomGrid(width, height, float64(density%80)/100, seed)
+		g.Impassable, g.Barriers = g.Barriers, make(map[Node]bool)
+		start := Node{int(sx) % width, int(sy) % height}
+		goal := Node{int(gx) % width, int(gy) % height}
+
+		path, cost := FindPath(g, start, goal)
+		ref, want := Dijkstra(g, start, goal)
+		if (path == nil) != (ref == nil) {
+			t.Fatalf("FindPath(%v, %v) = %v, Dijkstra %v", start, goal, path, ref)
+		}
+		if path == nil {
+			return
+		}
+		if err := g.checkPath(path, start, goal, cost); err != nil {
+			t.Fatalf("FindPath(%v, %v) = %v: %v", start, goal, path, err)
+		}
+		if cost != want {
+			t.Fatalf("FindPath(%v, %v) cost = %d, Dijkstra %d", start, goal, cost, want)
+		}
+	})
+}
+
+func TestDirectedArcs(t *testing.T) {
+	// a cheap slope from top down to bottom and a steep climb back, with a
+	// winding path up through switchback costing 2 a leg either way
+	top, bottom, switchback := Node{0, 0}, Node{1, 0}, Node{0, 1}
+	g := arcGraph{
+		top:        {{bottom, 1}, {switchback, 2}},
+		bottom:     {{top, 10}, {switchback, 2}},
+		switchback: {{top, 2}, {bottom, 2}},
+	}
+
+	if path, cost := FindPath(g, top, bottom); cost != 1 || !slices.Equal(path, []Node{top, bottom}) {
+		t.Errorf("downhill: FindPath = %v, %d; want the slope at cost 1", path, cost)
+	}
+	want := []Node{bottom, switchback, top}
+	if path, cost := FindPath(g, bottom, top); cost != 4 || !slices.Equal(path, want) {
+		t.Errorf("uphill: FindPath = %v, %d; want %v at cost 4", path, cost, want)
+	}
+
+	// on a grid, stepping onto heavy ground costs its weight but stepping
+	// off it does not
+	grid := NewGrid(2, 1)
+	grid.Weights[Node{1, 0}] = 5
+	for _, find := range []func(g *Grid, start, goal Node) ([]Node, Cost){
+		func(g *Grid, start, goal Node) ([]Node, Cost) { return FindPath(g, start, goal) },
+		FindPathReverse,
+	} {
+		if _, cost := find(grid, Node{0, 0}, Node{1, 0}); cost != 5 {
+			t.Errorf("onto the heavy cell costs %d, want 5", cost)
+		}
+		if _, cost := find(grid, Node{1, 0}, Node{0, 0}); cost != 1 {
+			t.Errorf("off the heavy cell costs %d, want 1", cost)
+		}
+	}
+}
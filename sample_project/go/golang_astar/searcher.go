@@ -0,0 +1,127 @@
+package golang_astar
+
+// Searcher holds the open set, closed set and node pool of a search so they
+// can be reused by the next one, leaving repeated queries to allocate only
+// what the graph's Neighbors and the returned path need. The zero value is
+// ready to use. A Searcher must not run more than one search at a time.
+type Searcher struct {
+	open      nodeHeap
+	openIndex map[Node]*searchNode
+	closedSet map[Node]*searchNode
+	pool      []*searchNode
+	used      int // nodes of pool handed out by the current search
+	stats     SearchStats
+
+	// best is the expanded node closest to the goal by the heuristic, with
+	// ties going to the cheaper one
+	best *searchNode
+
+	// BreakTies orders nodes of equal f by smaller h and then by how close
+	// they lie to the straight line from start to goal, giving straighter
+	// and more predictable paths than the default arbitrary order
+	BreakTies bool
+
+	// OnStep, when set, is called before each expansion with the positions
+	// in the open set, in no particular order. It is meant for debugging
+	// tools; the slice is a fresh copy each time, which makes searches slow.
+	OnStep func(frontier []Node)
+}
+
+// FindPath finds the shortest path between start and goal, reusing the
+// buffers left over from the Searcher's previous search
+func (s *Searcher) FindPath(graph Graph, start, goal Node) ([]Node, Cost) {
+	if outOfBounds(graph, start, goal) {
+		return nil, 0
+	}
+	path, cost, _ := s.search(graph, start, goal, searchOptions{h: defaultHeuristic(graph)})
+	return path, cost
+}
+
+// search runs a search on a throwaway Searcher
+func search(graph Graph, start, goal Node, opts searchOptions) ([]Node, Cost, error) {
+	var s Searcher
+	return s.search(graph, start, goal, opts)
+}
+
+// never is a goal test that keeps a search running until it runs out of
+// nodes
+func never(Node) bool { return false }
+
+// settled returns the cost of every node the last search expanded
+func (s *Searcher) settled() map[Node]Cost {
+	costs := make(map[Node]Cost, len(s.closedSet))
+	for n, node := range s.closedSet {
+		costs[n] = node.g
+	}
+	return costs
+}
+
+// parents returns the node each node the last search expanded was reached
+// from, leaving out the root
+func (s *Searcher) parents() map[Node]Node {
+	parents := make(map[Node]Node, len(s.closedSet))
+	for n, node := range s.closedSet {
+		if node.parent != nil {
+			parents[n] = node.parent.pos
+		}
+	}
+	return parents
+}
+
+// frontier copies the positions in the open set
+func (s *Searcher) frontier() []Node {
+	nodes := make([]Node, len(s.open))
+	for i, n := range s.open {
+		nodes[i] = n.pos
+	}
+	return nodes
+}
+
+// tieKey ranks n among nodes of equal f: nearer the goal first, then
+// nearer the straight line from start to goal
+func tieKey(n *searchNode, start, goal Node) [2]int {
+	dx1, dy1 := n.pos.X-goal.X, n.pos.Y-goal.Y
+	dx2, dy2 := start.X-goal.X, start.Y-goal.Y
+	return [2]int{int(n.h), abs(dx1*dy2 - dx2*dy1)}
+}
+
+// reset empties the buffers left over from the previous search
+func (s *Searcher) reset() {
+	clear(s.open)
+	s.open = s.open[:0]
+	if s.openIndex == nil {
+		s.openIndex = make(map[Node]*searchNode)
+		s.closedSet = make(map[Node]*searchNode)
+	} else {
+		clear(s.openIndex)
+		clear(s.closedSet)
+	}
+	s.used = 0
+	s.stats = SearchStats{}
+	s.best = nil
+}
+
+// newNode hands out a pooled searchNode initialized to n
+func (s *Searcher) newNode(n searchNode) *searchNode {
+	if s.used == len(s.pool) {
+		s.pool = append(s.pool, new(searchNode))
+	}
+	node := s.pool[s.used]
+	s.used++
+	*node = n
+	return node
+}
+
+// path lists the positions from the root of the search down to n
+func (n *searchNode) path() []Node {
+	length := 0
+	for p := n; p != nil; p = p.parent {
+		length++
+	}
+	path := make([]Node, length)
+	for p := n; p != nil; p = p.parent {
+		length--
+		path[length] = p.pos
+	}
+	return path
+}
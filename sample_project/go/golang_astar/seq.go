@@ -0,0 +1,17 @@
+package golang_astar
+
+import "iter"
+
+// FindPathSeq returns the shortest path between start and goal as a
+// sequence of nodes from start to goal. The search runs when iteration
+// starts and yields nothing if there is no path.
+func FindPathSeq(grid *Grid, start, goal Node) iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		path, _ := FindPath(grid, start, goal)
+		for _, n := range path {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
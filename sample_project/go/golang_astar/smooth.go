@@ -0,0 +1,43 @@
+package golang_astar
+
+// SmoothPath removes waypoints from path that can be skipped by walking in
+// a straight line, keeping only the points where the route has to turn.
+// Consecutive waypoints in the result are no longer adjacent cells. A
+// shortcut is only taken when the cells along its line are moves the grid
+// allows and cost no more than the stretch of path they replace, so
+// smoothing never leads through pricier terrain. A path the grid cannot
+// walk, as ValidatePath reports, is returned unchanged.
+func SmoothPath(grid *Grid, path []Node) []Node {
+	if len(path) < 3 {
+		return path
+	}
+
+	// costs[i] is the cost of walking path up to path[i]
+	costs := make([]Cost, len(path))
+	for i := 1; i < len(path); i++ {
+		step, ok := grid.arcCost(path[i-1], path[i])
+		if !ok {
+			return path
+		}
+		costs[i] = addCost(costs[i-1], step)
+	}
+
+	smoothed := []Node{path[0]}
+	for i := 0; i < len(path)-1; {
+		// jump to the furthest waypoint still in sight along a line no
+		// dearer than the path
+		next := i + 1
+		for j := len(path) - 1; j > next; j-- {
+			if !grid.LineOfSight(path[i], path[j]) {
+				continue
+			}
+			if cost, err := grid.PathCost(lineCells(path[i], path[j])); err == nil && cost <= costs[j]-costs[i] {
+				next = j
+				break
+			}
+		}
+		smoothed = append(smoothed, path[next])
+		i = next
+	}
+	return smoothed
+}
@@ -0,0 +1,58 @@
+package golang_astar
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSmoothPathDiagonalCorridor(t *testing.T) {
+	g := NewGrid(6, 6)
+	for x := 0; x < 6; x++ {
+		for y := 0; y < 6; y++ {
+			if x != y {
+				g.Impassable[Node{x, y}] = true
+			}
+		}
+	}
+	path, _ := FindPath(g, Node{0, 0}, Node{5, 5})
+	if got, want := SmoothPath(g, path), []Node{{0, 0}, {5, 5}}; !slices.Equal(got, want) {
+		t.Errorf("SmoothPath(%v) = %v, want %v", path, got, want)
+	}
+}
+
+func TestSmoothPathKeepsToCheapTerrain(t *testing.T) {
+	g := NewGrid(5, 3)
+	g.Weights[Node{2, 0}] = 10
+
+	// the path steps round the mud at (2,0); the straight line runs through it
+	path := []Node{{0, 0}, {1, 0}, {2, 1}, {3, 0}, {4, 0}}
+	smoothed := SmoothPath(g, path)
+	if want := []Node{{0, 0}, {2, 1}, {4, 0}}; !slices.Equal(smoothed, want) {
+		t.Fatalf("SmoothPath = %v, want %v", smoothed, want)
+	}
+
+	walked := []Node{smoothed[0]}
+	for i := 1; i < len(smoothed); i++ {
+		walked = append(walked, lineCells(smoothed[i-1], smoothed[i])[1:]...)
+	}
+	cost, err := g.PathCost(walked)
+	if want, _ := g.PathCost(path); err != nil || cost > want {
+		t.Errorf("smoothed route %v costs %d (%v), original %d", walked, cost, err, want)
+	}
+}
+
+func TestSmoothPathConnectivity4(t *testing.T) {
+	g := NewGrid(4, 4)
+	g.Connectivity = 4
+
+	// a staircase has no straight shortcuts when diagonal moves are not allowed
+	stairs := []Node{{0, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}}
+	if got := SmoothPath(g, stairs); !slices.Equal(got, stairs) {
+		t.Errorf("SmoothPath(%v) = %v, want it unchanged", stairs, got)
+	}
+
+	straight := []Node{{0, 0}, {1, 0}, {2, 0}, {3, 0}}
+	if got, want := SmoothPath(g, straight), []Node{{0, 0}, {3, 0}}; !slices.Equal(got, want) {
+		t.Errorf("SmoothPath(%v) = %v, want %v", straight, got, want)
+	}
+}
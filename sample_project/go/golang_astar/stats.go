@@ -0,0 +1,28 @@
+package golang_astar
+
+// SearchStats records how much work a search did
+type SearchStats struct {
+	NodesExpanded  int // nodes popped from the open set
+	NodesGenerated int // nodes pushed onto the open set
+	MaxOpenSetSize int // largest size the open set reached
+}
+
+// generated records a push that left the open set at the given size
+func (s *SearchStats) generated(openSetSize int) {
+	s.NodesGenerated++
+	if openSetSize > s.MaxOpenSetSize {
+		s.MaxOpenSetSize = openSetSize
+	}
+}
+
+// FindPathStats finds the shortest path between start and goal and reports
+// how much work the search did. If start or goal lies outside the grid it
+// returns nil without searching, and the stats are all zero.
+func FindPathStats(grid *Grid, start, goal Node) ([]Node, Cost, SearchStats) {
+	var stats SearchStats
+	if outOfBounds(grid, start, goal) {
+		return nil, 0, stats
+	}
+	path, cost, _ := search(grid, start, goal, searchOptions{h: defaultHeuristic(grid), stats: &stats})
+	return path, cost, stats
+}
@@ -0,0 +1,23 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathStats(t *testing.T) {
+	g := NewGrid(5, 5)
+	path, cost, stats := FindPathStats(g, Node{0, 0}, Node{4, 4})
+	if cost != 4 || len(path) != 5 {
+		t.Fatalf("FindPathStats = %v, %d; want the diagonal at cost 4", path, cost)
+	}
+	if stats.NodesExpanded == 0 || stats.NodesGenerated < stats.NodesExpanded || stats.MaxOpenSetSize == 0 {
+		t.Errorf("stats = %+v, want some work recorded", stats)
+	}
+
+	for _, tt := range []struct{ start, goal Node }{
+		{Node{-1, 0}, Node{4, 4}},
+		{Node{0, 0}, Node{5, 4}},
+	} {
+		if path, cost, stats := FindPathStats(g, tt.start, tt.goal); path != nil || stats != (SearchStats{}) {
+			t.Errorf("FindPathStats(%v, %v) = %v, %d, %+v; want nil and no work", tt.start, tt.goal, path, cost, stats)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package golang_astar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SVGStyle sets the cell size and colors RenderSVGWithStyle draws with.
+// Colors are any SVG color, such as "#333" or "tomato"; zero fields take
+// the value from DefaultSVGStyle.
+type SVGStyle struct {
+	CellSize   int
+	Open       string
+	Barrier    string
+	Impassable string
+	GridLine   string
+	Path       string
+	Start      string
+	Goal       string
+}
+
+// DefaultSVGStyle is the style RenderSVG uses
+var DefaultSVGStyle = SVGStyle{
+	CellSize:   20,
+	Open:       "#ffffff",
+	Barrier:    "#555555",
+	Impassable: "#111111",
+	GridLine:   "#dddddd",
+	Path:       "#1e6fd9",
+	Start:      "#2ca02c",
+	Goal:       "#d62728",
+}
+
+// withDefaults fills the zero fields of s from DefaultSVGStyle
+func (s SVGStyle) withDefaults() SVGStyle {
+	d := DefaultSVGStyle
+	if s.CellSize <= 0 {
+		s.CellSize = d.CellSize
+	}
+	for _, f := range []struct {
+		field *string
+		def   string
+	}{
+		{&s.Open, d.Open},
+		{&s.Barrier, d.Barrier},
+		{&s.Impassable, d.Impassable},
+		{&s.GridLine, d.GridLine},
+		{&s.Path, d.Path},
+		{&s.Start, d.Start},
+		{&s.Goal, d.Goal},
+	} {
+		if *f.field == "" {
+			*f.field = f.def
+		}
+	}
+	return s
+}
+
+// RenderSVG draws the grid and path as an SVG image in DefaultSVGStyle,
+// the vector counterpart of RenderPath
+func (g *Grid) RenderSVG(w io.Writer, path []Node) error {
+	return g.RenderSVGWithStyle(w, path, DefaultSVGStyle)
+}
+
+// RenderSVGWithStyle draws the grid and path as an SVG image: one square per
+// cell, colored for barriers and impassable cells, and the path as a line
+// through cell centers with its first and last nodes marked as start and
+// goal
+func (g *Grid) RenderSVGWithStyle(w io.Writer, path []Node, style SVGStyle) error {
+	s := style.withDefaults()
+	size := s.CellSize
+	width, height := max(g.Width, 0)*size, max(g.Height, 0)*size
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", width, height, s.Open)
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			n := Node{x, y}
+			fill := ""
+			switch {
+			case g.Impassable[n]:
+				fill = s.Impassable
+			case g.IsBarrier(n):
+				fill = s.Barrier
+			}
+			if fill != "" {
+				fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+					x*size, y*size, size, size, fill)
+			}
+		}
+	}
+
+	// grid lines between cells
+	fmt.Fprintf(&b, `<g stroke="%s" stroke-width="1">`+"\n", s.GridLine)
+	for x := 0; x <= g.Width; x++ {
+		fmt.Fprintf(&b, `<line x1="%d" y1="0" x2="%d" y2="%d"/>`+"\n", x*size, x*size, height)
+	}
+	for y := 0; y <= g.Height; y++ {
+		fmt.Fprintf(&b, `<line x1="0" y1="%d" x2="%d" y2="%d"/>`+"\n", y*size, width, y*size)
+	}
+	b.WriteString("</g>\n")
+
+	if len(path) > 0 {
+		center := func(n Node) (int, int) {
+			return n.X*size + size/2, n.Y*size + size/2
+		}
+		points := make([]string, len(path))
+		for i, n := range path {
+			cx, cy := center(n)
+			points[i] = fmt.Sprintf("%d,%d", cx, cy)
+		}
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="%d" stroke-linecap="round" stroke-linejoin="round"/>`+"\n",
+			strings.Join(points, " "), s.Path, max(size/5, 1))
+		for _, mark := range []struct {
+			n     Node
+			color string
+		}{{path[0], s.Start}, {path[len(path)-1], s.Goal}} {
+			cx, cy := center(mark.n)
+			fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n", cx, cy, max(size/3, 1), mark.color)
+		}
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,46 @@
+package golang_astar
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestRenderSVG(t *testing.T) {
+	g := NewGrid(4, 3)
+	g.Barriers[Node{1, 0}] = true
+	g.Barriers[Node{2, 1}] = true
+	g.Impassable[Node{0, 2}] = true
+	path := []Node{{0, 0}, {1, 1}, {3, 2}}
+
+	var buf bytes.Buffer
+	if err := g.RenderSVGWithStyle(&buf, path, SVGStyle{CellSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	var svg struct {
+		ViewBox string `xml:"viewBox,attr"`
+		Rects   []struct {
+			Fill string `xml:"fill,attr"`
+		} `xml:"rect"`
+		Polyline struct {
+			Points string `xml:"points,attr"`
+		} `xml:"polyline"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &svg); err != nil {
+		t.Fatalf("output is not XML: %v\n%s", err, buf.String())
+	}
+
+	if svg.ViewBox != "0 0 40 30" {
+		t.Errorf("viewBox = %q, want %q", svg.ViewBox, "0 0 40 30")
+	}
+	fills := make(map[string]int)
+	for _, r := range svg.Rects {
+		fills[r.Fill]++
+	}
+	if fills[DefaultSVGStyle.Barrier] != 2 || fills[DefaultSVGStyle.Impassable] != 1 {
+		t.Errorf("rect fills = %v, want 2 barriers and 1 impassable cell", fills)
+	}
+	if want := "5,5 15,15 35,25"; svg.Polyline.Points != want {
+		t.Errorf("polyline points = %q, want %q", svg.Polyline.Points, want)
+	}
+}
@@ -0,0 +1,68 @@
+package golang_astar
+
+import "container/heap"
+
+// euclidean returns the straight-line distance between a and b in units of
+// the grid's orthogonal step cost
+func (g *Grid) euclidean(a, b Node) Cost {
+	ortho, _ := g.stepCosts()
+	return Cost(float64(ortho) * a.EuclideanDistance(b))
+}
+
+// FindPathThetaStar finds an any-angle path between start and goal. Whenever
+// a neighbor is in line of sight of the current node's parent it is linked
+// to that parent directly, so the path can cut across open space instead of
+// following 45-degree grid moves.
+//
+// The returned path lists only the turning points, and straight segments
+// cost their Euclidean length in units of the orthogonal step cost. Set the
+// grid's OrthogonalCost to something like 10 so those lengths don't lose
+// too much to rounding. The result is short but not guaranteed optimal. If
+// start or goal lies outside the grid it returns nil.
+func FindPathThetaStar(grid *Grid, start, goal Node) ([]Node, Cost) {
+	if outOfBounds(grid, start, goal) {
+		return nil, 0
+	}
+	openSet := &nodeHeap{}
+	startNode := &searchNode{pos: start, h: grid.euclidean(start, goal)}
+	startNode.f = startNode.h
+	heap.Push(openSet, startNode)
+
+	reached := map[Node]*searchNode{start: startNode}
+	closedSet := make(map[Node]bool)
+
+	for openSet.Len() > 0 {
+		current := heap.Pop(openSet).(*searchNode)
+		if current.pos == goal {
+			return current.path(), current.g
+		}
+		closedSet[current.pos] = true
+
+		for _, arc := range grid.Neighbors(current.pos) {
+			if closedSet[arc.To] {
+				continue
+			}
+
+			// Prefer a straight line from the parent over the grid move
+			parent, g := current, addCost(current.g, arc.Cost)
+			if p := current.parent; p != nil && grid.LineOfSight(p.pos, arc.To) {
+				parent, g = p, addCost(p.g, grid.euclidean(p.pos, arc.To))
+			}
+
+			neighbor, ok := reached[arc.To]
+			if !ok {
+				neighbor = &searchNode{pos: arc.To, parent: parent, g: g, h: grid.euclidean(arc.To, goal)}
+				neighbor.f = addCost(g, neighbor.h)
+				reached[arc.To] = neighbor
+				heap.Push(openSet, neighbor)
+			} else if g < neighbor.g {
+				neighbor.parent = parent
+				neighbor.g = g
+				neighbor.f = addCost(g, neighbor.h)
+				heap.Fix(openSet, neighbor.index)
+			}
+		}
+	}
+
+	return nil, 0 // No path found
+}
@@ -0,0 +1,38 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathThetaStarCutsCorners(t *testing.T) {
+	g := NewGrid(20, 20)
+	g.OrthogonalCost, g.DiagonalCost = 10, 14
+	for y := 0; y < 12; y++ {
+		g.Impassable[Node{10, y}] = true
+	}
+	start, goal := Node{0, 0}, Node{19, 3}
+
+	_, gridCost := FindPath(g, start, goal)
+	path, cost := FindPathThetaStar(g, start, goal)
+	if path == nil || cost >= gridCost {
+		t.Fatalf("FindPathThetaStar = %v, %d; want cheaper than FindPath's %d", path, cost, gridCost)
+	}
+	if path[0] != start || path[len(path)-1] != goal {
+		t.Fatalf("path %v does not run from %v to %v", path, start, goal)
+	}
+	for i := 1; i < len(path); i++ {
+		if !g.LineOfSight(path[i-1], path[i]) {
+			t.Errorf("no line of sight between waypoints %v and %v", path[i-1], path[i])
+		}
+	}
+}
+
+func TestFindPathThetaStarOutOfBounds(t *testing.T) {
+	g := NewGrid(5, 5)
+	for _, tt := range []struct{ start, goal Node }{
+		{Node{-1, 0}, Node{4, 4}},
+		{Node{0, 0}, Node{4, 5}},
+	} {
+		if path, cost := FindPathThetaStar(g, tt.start, tt.goal); path != nil {
+			t.Errorf("FindPathThetaStar(%v, %v) = %v, %d; want nil", tt.start, tt.goal, path, cost)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package golang_astar
+
+// turnState is a position together with the step that led to it, so a
+// search over states can charge for changing direction. done marks the
+// single state every goal state leads to.
+type turnState struct {
+	pos, dir Node
+	done     bool
+}
+
+// FindPathWithTurnPenalty finds the cheapest path between start and goal
+// when every change of direction costs penalty on top of the moves, as for
+// vehicles that would rather keep going straight. Since the cost of a move
+// depends on the move before it, the search runs over (position, heading)
+// states with FindPathG. Moves through portals set no heading, so neither
+// they nor the move after one are penalized. The cost returned includes the
+// penalties.
+func FindPathWithTurnPenalty(grid *Grid, start, goal Node, penalty Cost) ([]Node, Cost) {
+	return turnSearch(grid, start, goal, 1, penalty)
+}
+
+// FindPathFewestTurns finds the shortest path between start and goal and,
+// among paths of that cost, the one with the fewest changes of direction,
+// which gives cleaner routes for pipes and roads. Move costs are scaled up
+// so that no number of turns can outweigh a single unit of cost, and each
+// turn then costs 1.
+func FindPathFewestTurns(grid *Grid, start, goal Node) ([]Node, Cost) {
+	scale := Cost(max(grid.Width*grid.Height, 0) + 1)
+	path, cost := turnSearch(grid, start, goal, scale, 1)
+	return path, cost / scale
+}
+
+// turnSearch finds the cheapest path between start and goal when moves cost
+// scale times their grid cost and every change of direction costs penalty
+func turnSearch(grid *Grid, start, goal Node, scale, penalty Cost) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+
+	end := turnState{done: true}
+	h := defaultHeuristic(grid)
+	neighbors := func(s turnState) []ArcG[turnState] {
+		if s.pos == goal {
+			return []ArcG[turnState]{{end, 0}}
+		}
+		var arcs []ArcG[turnState]
+		for _, arc := range grid.Neighbors(s.pos) {
+			next := turnState{pos: arc.To}
+			// measure the step the short way round on a wrapping grid
+			if d := grid.nearestCopy(s.pos, arc.To).Sub(s.pos); isAdjacent(Node{}, d) {
+				next.dir = d
+			}
+			cost := mulCost(arc.Cost, scale)
+			if s.dir != (Node{}) && next.dir != (Node{}) && next.dir != s.dir {
+				cost = addCost(cost, penalty)
+			}
+			arcs = append(arcs, ArcG[turnState]{next, cost})
+		}
+		return arcs
+	}
+	estimate := func(s, _ turnState) Cost {
+		if s.done {
+			return 0
+		}
+		return mulCost(h(s.pos, goal), scale)
+	}
+
+	states, cost := FindPathG(turnState{pos: start}, end, neighbors, estimate)
+	if states == nil {
+		return nil, 0
+	}
+	path := make([]Node, len(states)-1)
+	for i, s := range states[:len(states)-1] {
+		path[i] = s.pos
+	}
+	return path, cost
+}
@@ -0,0 +1,101 @@
+package golang_astar
+
+import (
+	"slices"
+	"testing"
+)
+
+// turns counts the changes of direction along path
+func turns(path []Node) int {
+	n := 0
+	for i := 2; i < len(path); i++ {
+		if path[i].Sub(path[i-1]) != path[i-1].Sub(path[i-2]) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestFindPathWithTurnPenalty(t *testing.T) {
+	// a staircase corridor from (1,0) to (6,5) takes 10 moves and 9 turns;
+	// the way round the edge takes 14 moves and 3 turns
+	g, err := NewGridFromStrings([]string{
+		"...####",
+		".#..###",
+		".##..##",
+		".###..#",
+		".####..",
+		".#####.",
+		".......",
+	}, '#')
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Impassable, g.Barriers = g.Barriers, make(map[Node]bool)
+	g.Connectivity = 4
+	start, goal := Node{1, 0}, Node{6, 5}
+
+	tests := []struct {
+		penalty Cost
+		cost    Cost
+		turns   int
+	}{
+		{0, 10, 9},
+		{1, 14 + 3, 3},
+	}
+	for _, tt := range tests {
+		path, cost := FindPathWithTurnPenalty(g, start, goal, tt.penalty)
+		if cost != tt.cost || turns(path) != tt.turns {
+			t.Errorf("penalty %d: got %v at %d with %d turns; want cost %d with %d turns",
+				tt.penalty, path, cost, turns(path), tt.cost, tt.turns)
+		}
+	}
+}
+
+func TestFindPathFewestTurns(t *testing.T) {
+	g := NewGrid(8, 8)
+	g.Connectivity = 4
+	start, goal := Node{0, 0}, Node{5, 5}
+
+	path, cost := FindPathFewestTurns(g, start, goal)
+	if _, want := FindPath(g, start, goal); cost != want {
+		t.Errorf("cost = %d, want the shortest %d", cost, want)
+	}
+	if turns(path) != 1 {
+		t.Errorf("path %v turns %d times, want once", path, turns(path))
+	}
+}
+
+func TestFindPathWithTurnPenaltyAcrossSeam(t *testing.T) {
+	// straight west from (1,0) over the seam to (4,0) keeps its heading
+	g := NewGrid(6, 1)
+	g.WrapX = true
+	if path, cost := FindPathWithTurnPenalty(g, Node{1, 0}, Node{4, 0}, 10); cost != 3 {
+		t.Errorf("FindPathWithTurnPenalty = %v, %d; want straight across at cost 3", path, cost)
+	}
+
+	// and turning just past the seam still costs a turn
+	g = NewGrid(6, 2)
+	g.WrapX = true
+	g.Connectivity = 4
+	g.Impassable[Node{1, 1}] = true
+	g.Impassable[Node{0, 1}] = true
+	path, cost := FindPathWithTurnPenalty(g, Node{1, 0}, Node{5, 1}, 10)
+	if want := []Node{{1, 0}, {0, 0}, {5, 0}, {5, 1}}; cost != 3+10 || !slices.Equal(path, want) {
+		t.Errorf("FindPathWithTurnPenalty = %v, %d; want %v at cost 13", path, cost, want)
+	}
+}
+
+func TestFindPathWithTurnPenaltyThroughPortal(t *testing.T) {
+	g := NewGrid(8, 2)
+	g.Connectivity = 4
+	if err := g.AddPortal(Node{2, 0}, Node{6, 0}, 0); err != nil {
+		t.Fatal(err)
+	}
+	// two steps east, through the portal and one step south: neither the
+	// portal move nor the turn after it is penalized
+	path, cost := FindPathWithTurnPenalty(g, Node{0, 0}, Node{6, 1}, 10)
+	if want := []Node{{0, 0}, {1, 0}, {2, 0}, {6, 0}, {6, 1}}; cost != 3 || !slices.Equal(path, want) {
+		t.Errorf("FindPathWithTurnPenalty = %v, %d; want %v at cost 3", path, cost, want)
+	}
+}
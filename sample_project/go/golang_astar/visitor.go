@@ -0,0 +1,49 @@
+package golang_astar
+
+// FindPathWithVisitor finds the shortest path between start and goal,
+// calling onExpand with the position and scores of each node as it is popped
+// from the open set. onExpand only receives copies, so it can record the
+// search but not steer it. If start or goal lies outside the grid it
+// returns nil without calling onExpand.
+func FindPathWithVisitor(grid *Grid, start, goal Node, onExpand func(n Node, g, h, f Cost)) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+	path, cost, _ := search(grid, start, goal, searchOptions{h: defaultHeuristic(grid), onExpand: onExpand})
+	return path, cost
+}
+
+// FindPathWithExplored finds the shortest path between start and goal and
+// also returns every cell the search expanded, in no particular order, for
+// drawing the area it explored
+func FindPathWithExplored(grid *Grid, start, goal Node) ([]Node, Cost, []Node) {
+	var explored []Node
+	seen := make(map[Node]bool)
+	path, cost := FindPathWithVisitor(grid, start, goal, func(n Node, _, _, _ Cost) {
+		// a reopened node is expanded again but listed once
+		if !seen[n] {
+			seen[n] = true
+			explored = append(explored, n)
+		}
+	})
+	return path, cost, explored
+}
+
+// FindPathTree finds the shortest path between start and goal and also
+// returns the search tree: for every node the search expanded, goal
+// included, the node it was reached from. Following parents from any of
+// them leads back to start along the cheapest path the search knew of. The
+// map is built afresh for each call and start has no entry.
+func FindPathTree(grid *Grid, start, goal Node) ([]Node, Cost, map[Node]Node) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0, nil
+	}
+	var s Searcher
+	path, cost, _ := s.search(grid, start, goal, searchOptions{h: defaultHeuristic(grid)})
+	parents := s.parents()
+	// the search returns on reaching goal without closing it
+	if len(path) > 1 {
+		parents[goal] = path[len(path)-2]
+	}
+	return path, cost, parents
+}
@@ -0,0 +1,37 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathWithVisitor(t *testing.T) {
+	g := NewGrid(10, 10)
+	start, goal := Node{0, 0}, Node{9, 4}
+
+	var expanded []Node
+	path, cost := FindPathWithVisitor(g, start, goal, func(n Node, gScore, h, f Cost) {
+		if f != gScore+h {
+			t.Errorf("%v: f = %d, want g + h = %d", n, f, gScore+h)
+		}
+		expanded = append(expanded, n)
+	})
+	if _, want := FindPath(g, start, goal); path == nil || cost != want {
+		t.Fatalf("FindPathWithVisitor = %v, %d; want cost %d", path, cost, want)
+	}
+	if len(expanded) == 0 || expanded[0] != start {
+		t.Errorf("expanded %v, want start first", expanded)
+	}
+}
+
+func TestFindPathWithVisitorOutOfBounds(t *testing.T) {
+	g := NewGrid(5, 5)
+	visit := func(n Node, _, _, _ Cost) {
+		t.Errorf("visited %v", n)
+	}
+	for _, n := range []Node{{-1, 0}, {0, -1}, {5, 0}, {0, 5}} {
+		if path, _ := FindPathWithVisitor(g, n, Node{2, 2}, visit); path != nil {
+			t.Errorf("start %v: got path %v", n, path)
+		}
+		if path, _ := FindPathWithVisitor(g, Node{2, 2}, n, visit); path != nil {
+			t.Errorf("goal %v: got path %v", n, path)
+		}
+	}
+}
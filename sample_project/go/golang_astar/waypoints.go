@@ -0,0 +1,32 @@
+package golang_astar
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FindPathThrough finds the shortest path that visits waypoints in order,
+// joining the shortest path of each leg without repeating the waypoints
+// where legs meet. If a leg cannot be walked the error names it and wraps
+// the reason, such as ErrNoPath.
+func FindPathThrough(grid *Grid, waypoints []Node) ([]Node, Cost, error) {
+	if len(waypoints) == 0 {
+		return nil, 0, errors.New("astar: no waypoints to visit")
+	}
+	if !grid.IsValidPosition(waypoints[0]) {
+		return nil, 0, fmt.Errorf("astar: waypoint 0 at %v: %w", waypoints[0], ErrStartInvalid)
+	}
+
+	path := []Node{waypoints[0]}
+	var total Cost
+	for i := 1; i < len(waypoints); i++ {
+		from, to := waypoints[i-1], waypoints[i]
+		leg, cost, err := FindPathE(grid, from, to)
+		if err != nil {
+			return nil, 0, fmt.Errorf("astar: leg %d from %v to %v: %w", i, from, to, err)
+		}
+		path = append(path, leg[1:]...)
+		total = addCost(total, cost)
+	}
+	return path, total, nil
+}
@@ -0,0 +1,28 @@
+package golang_astar
+
+// FindPathWeighted finds a path between start and goal using weighted A*,
+// ordering the search by g + epsilon*h. An epsilon of 1 is plain A*; larger
+// values head for the goal more greedily and expand fewer nodes, returning
+// a path that costs at most epsilon times the optimal cost. Nodes are never
+// reopened, since the bound holds without it. Like FindPath, it returns nil
+// if start or goal lies outside the grid.
+func FindPathWeighted(grid *Grid, start, goal Node, epsilon float64) ([]Node, Cost) {
+	if !grid.IsValidPosition(start) || !grid.IsValidPosition(goal) {
+		return nil, 0
+	}
+	path, cost, _ := search(grid, start, goal, searchOptions{
+		h:        inflate(defaultHeuristic(grid), epsilon),
+		noReopen: true,
+	})
+	return path, cost
+}
+
+// inflate scales the estimates of h by epsilon
+func inflate(h func(a, b Node) Cost, epsilon float64) func(a, b Node) Cost {
+	return func(a, b Node) Cost {
+		if est := epsilon * float64(h(a, b)); est < float64(MaxCost) {
+			return Cost(est)
+		}
+		return MaxCost
+	}
+}
@@ -0,0 +1,48 @@
+package golang_astar
+
+import "testing"
+
+func TestFindPathWeightedExpandsFewerNodes(t *testing.T) {
+	g := NewGrid(40, 40)
+	g.Connectivity = 4
+	for y := 0; y < 30; y++ {
+		g.Impassable[Node{20, y}] = true
+	}
+	start, goal := Node{0, 0}, Node{39, 0}
+	_, optimal := FindPath(g, start, goal)
+
+	expanded := func(epsilon float64) int {
+		var stats SearchStats
+		search(g, start, goal, searchOptions{
+			h:        inflate(defaultHeuristic(g), epsilon),
+			noReopen: true,
+			stats:    &stats,
+		})
+		return stats.NodesExpanded
+	}
+	if plain, greedy := expanded(1), expanded(3); greedy >= plain {
+		t.Errorf("epsilon 3 expanded %d nodes, epsilon 1 %d; want fewer", greedy, plain)
+	}
+
+	for _, epsilon := range []float64{1, 1.5, 3} {
+		path, cost := FindPathWeighted(g, start, goal, epsilon)
+		if path == nil || float64(cost) > epsilon*float64(optimal) {
+			t.Errorf("epsilon %v: cost %d, want at most %v", epsilon, cost, epsilon*float64(optimal))
+		}
+	}
+	if _, cost := FindPathWeighted(g, start, goal, 1); cost != optimal {
+		t.Errorf("epsilon 1: cost %d, want optimal %d", cost, optimal)
+	}
+}
+
+func TestFindPathWeightedOutOfBounds(t *testing.T) {
+	g := NewGrid(5, 5)
+	for _, n := range []Node{{-1, 0}, {0, 5}} {
+		if path, _ := FindPathWeighted(g, n, Node{2, 2}, 2); path != nil {
+			t.Errorf("start %v: got path %v", n, path)
+		}
+		if path, _ := FindPathWeighted(g, Node{2, 2}, n, 2); path != nil {
+			t.Errorf("goal %v: got path %v", n, path)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package golang_astar
+
+import "testing"
+
+// wrapSearches are the searches that must take the short way across the
+// edges of a wrapping grid
+var wrapSearches = map[string]func(g *Grid, start, goal Node) ([]Node, Cost){
+	"FindPath":              func(g *Grid, start, goal Node) ([]Node, Cost) { return FindPath(g, start, goal) },
+	"FindPathBidirectional": FindPathBidirectional,
+	"FindPathMulti": func(g *Grid, start, goal Node) ([]Node, Cost) {
+		path, cost, _ := FindPathMulti(g, start, []Node{goal})
+		return path, cost
+	},
+	"DStarLite": func(g *Grid, start, goal Node) ([]Node, Cost) {
+		var d DStarLite
+		d.Init(g, start, goal)
+		d.ComputeShortestPath()
+		return d.Path()
+	},
+}
+
+func TestWrapSeam(t *testing.T) {
+	g := NewGrid(20, 20)
+	g.Wrap = true
+	start, goal := Node{2, 1}, Node{17, 18}
+
+	// five steps left across the seam and three up, three of them diagonal
+	for name, find := range wrapSearches {
+		path, cost := find(g, start, goal)
+		if cost != 5 {
+			t.Errorf("%s = %v, %d; want cost 5", name, path, cost)
+			continue
+		}
+		if err := g.checkPath(path, start, goal, cost); err != nil {
+			t.Errorf("%s = %v: %v", name, path, err)
+		}
+	}
+}
+
+func TestFindPathMultiNearestAcrossSeam(t *testing.T) {
+	g := NewGrid(20, 1)
+	g.WrapX = true
+	// (9,0) looks nearer, but (17,0) is five steps back across the seam
+	path, cost, reached := FindPathMulti(g, Node{2, 0}, []Node{{17, 0}, {9, 0}})
+	if cost != 5 || reached != (Node{17, 0}) {
+		t.Errorf("FindPathMulti = %v, %d, %v; want (17,0) at cost 5", path, cost, reached)
+	}
+}
+
+func TestDStarLiteWrapMatchesDijkstra(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		g := NewRandomGrid(16, 12, 0.25, seed)
+		g.Wrap = true
+		start, goal := Node{1, 1}, Node{14, 10}
+		_, want := Dijkstra(g, start, goal)
+
+		var d DStarLite
+		d.Init(g, start, goal)
+		d.ComputeShortestPath()
+		if path, cost := d.Path(); cost != want {
+			t.Errorf("seed %d: DStarLite = %v, %d; want cost %d", seed, path, cost, want)
+		}
+
+		// and again after a wall goes up across the seam
+		d.UpdateCell(Node{0, 1}, true)
+		d.UpdateCell(Node{15, 0}, true)
+		d.ComputeShortestPath()
+		_, want = Dijkstra(g, start, goal)
+		if path, cost := d.Path(); cost != want {
+			t.Errorf("seed %d after update: DStarLite = %v, %d; want cost %d", seed, path, cost, want)
+		}
+	}
+}
+
+func TestWrapSingleAxisSeam(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(g *Grid)
+		start, goal Node
+		cost        Cost
+	}{
+		{"WrapX across", func(g *Grid) { g.WrapX = true }, Node{2, 5}, Node{17, 5}, 5},
+		{"WrapX not down", func(g *Grid) { g.WrapX = true }, Node{5, 2}, Node{5, 17}, 15},
+		{"WrapY across", func(g *Grid) { g.WrapY = true }, Node{5, 2}, Node{5, 17}, 5},
+		{"WrapY not sideways", func(g *Grid) { g.WrapY = true }, Node{2, 5}, Node{17, 5}, 15},
+	}
+	for _, tt := range tests {
+		for name, find := range wrapSearches {
+			g := NewGrid(20, 20)
+			tt.setup(g)
+			path, cost := find(g, tt.start, tt.goal)
+			if cost != tt.cost {
+				t.Errorf("%s: %s = %v, %d; want cost %d", tt.name, name, path, cost, tt.cost)
+				continue
+			}
+			if err := g.checkPath(path, tt.start, tt.goal, cost); err != nil {
+				t.Errorf("%s: %s = %v: %v", tt.name, name, path, err)
+			}
+		}
+	}
+}